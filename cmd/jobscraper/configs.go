@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goodsun/jobscraper/config"
+	"github.com/goodsun/jobscraper/extract"
+	"github.com/goodsun/jobscraper/output"
+)
+
+func runListConfigs(args []string) error {
+	flags := newFlagSet("list-configs", "[options]")
+	searchPath := configDirFlag(flags)
+	if _, err := parseArgs(flags, args); err != nil {
+		return err
+	}
+
+	search := searchPath()
+	entries, err := search.List()
+	if err != nil {
+		return fmt.Errorf("reading config directory: %v", err)
+	}
+
+	fmt.Println("Available Site Configurations")
+	fmt.Println("=============================")
+	fmt.Println()
+
+	for _, entry := range entries {
+		// どの置き場所の設定が使われるか（上書きした置き場所も併記）
+		source := entry.Source.Name
+		for _, overridden := range entry.Overrides {
+			source += ", overrides " + overridden.Name
+		}
+
+		if entry.Err != nil {
+			fmt.Printf("%-20s (error parsing config) [%s]\n", entry.Name, source)
+			continue
+		}
+
+		domain := entry.Config.Domain
+		if domain == "" {
+			domain = "no domain specified"
+		}
+		fmt.Printf("%-20s - %-24s [%s]\n", entry.Name, domain, source)
+	}
+
+	fmt.Println()
+	fmt.Println("Search path:")
+	for i, source := range search {
+		fmt.Printf("  %d. %s\n", i+1, source)
+	}
+	fmt.Println()
+	fmt.Println("Usage: jobscraper extract --config <name> <url>")
+	return nil
+}
+
+func runShowConfig(args []string) error {
+	flags := newFlagSet("show-config", "[options] <name|file>")
+	searchPath := configDirFlag(flags)
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 1 {
+		flags.Usage()
+		return fmt.Errorf("site config name is required")
+	}
+
+	// extends / include を展開した設定を表示
+	var resolved map[string]interface{}
+	if name := positional[0]; filepath.Ext(name) != "" || strings.ContainsRune(name, filepath.Separator) {
+		resolved, err = searchPath().ResolveFile(name)
+	} else {
+		resolved, err = searchPath().Resolve(name)
+	}
+	if err != nil {
+		return fmt.Errorf("resolving config: %v", err)
+	}
+	return output.WriteConfig(resolved, "")
+}
+
+func runValidateConfigs(args []string) error {
+	flags := newFlagSet("validate-configs", "[options] [config file ...]")
+	printSchema := flags.Bool("print-schema", false, "サイト設定のJSON Schemaを表示")
+	searchPath := configDirFlag(flags)
+	files, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if *printSchema {
+		fmt.Print(string(config.SiteConfigSchema))
+		return nil
+	}
+
+	// 指定がなければ検索パス上の sites/ と fragments/ の全ファイル
+	validator := &config.Validator{Search: searchPath(), HasPlugin: extract.HasPlugin, IsSelectorField: extract.IsSelectorField}
+	var issues []config.Issue
+	checked := len(files)
+	if len(files) == 0 {
+		issues, checked = validator.ValidateSearchPath()
+	} else {
+		issues = validator.Validate(files)
+	}
+
+	errorCount, warningCount := 0, 0
+	for _, issue := range issues {
+		fmt.Println(issue)
+		if issue.Severity == "error" {
+			errorCount++
+		} else {
+			warningCount++
+		}
+	}
+
+	fmt.Printf("%d files checked: %d errors, %d warnings\n", checked, errorCount, warningCount)
+	if errorCount > 0 {
+		return fmt.Errorf("%d errors in site configs", errorCount)
+	}
+	return nil
+}
+
+func runConvertConfig(args []string) error {
+	flags := newFlagSet("convert-config", "[options] <xpath_config.json> [output.json]")
+	sampleURL := flags.String("url", "", "対象サイトの求人ページのURL（name と domain を推測）")
+	name := flags.String("name", "", "サイト設定名（省略時はURLまたはファイル名から）")
+	useCSS := flags.Bool("css", false, "CSSセレクターに書き換えられるフィールドは selector 型の extractor にする")
+	searchPath := configDirFlag(flags)
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 1 {
+		flags.Usage()
+		return fmt.Errorf("XPath config file is required")
+	}
+	configFile, outputFile := positional[0], argAt(positional, 1)
+
+	xpaths, err := config.LoadXPathConfig(configFile)
+	if err != nil {
+		return fmt.Errorf("reading config file: %v", err)
+	}
+
+	opts := config.ConvertOptions{Name: *name, UseCSS: *useCSS}
+	if *sampleURL != "" {
+		inferred, domain, err := config.InferSiteName(*sampleURL)
+		if err != nil {
+			return fmt.Errorf("invalid URL: %v", err)
+		}
+		opts.Domain = domain
+		if opts.Name == "" {
+			opts.Name = inferred
+		}
+	}
+	if opts.Name == "" {
+		opts.Name = strings.TrimSuffix(filepath.Base(configFile), filepath.Ext(configFile))
+	}
+
+	conversion, err := config.ConvertXPathConfig(xpaths, opts)
+	if err != nil {
+		return err
+	}
+
+	// 変換結果を標準出力に書く場合もJSONだけになるよう、経過は標準エラーに出す
+	search := searchPath()
+	if entries, err := search.List(); err == nil {
+		for _, entry := range entries {
+			if entry.Name == opts.Name {
+				conversion.Warnings = append(conversion.Warnings, fmt.Sprintf("a site config named %q already exists (%s); rename it with --name or merge them", opts.Name, entry.Path))
+			}
+		}
+	}
+	if *sampleURL != "" {
+		if detected := search.Detect(*sampleURL); detected != "default" && detected != opts.Name {
+			conversion.Warnings = append(conversion.Warnings, fmt.Sprintf("the URL is already detected as %q", detected))
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "%d of %d fields can use CSS selectors:\n", len(conversion.Proposals), len(conversion.Config["extractors"].(map[string]interface{})))
+	for _, proposal := range conversion.Proposals {
+		selector := proposal.Selector
+		if proposal.Attr != "" {
+			selector += " (attr: " + proposal.Attr + ")"
+		}
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", proposal.Field, selector)
+	}
+	for _, warning := range conversion.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	if err := output.WriteConfig(conversion.Config, outputFile); err != nil {
+		return fmt.Errorf("writing output: %v", err)
+	}
+	if outputFile != "" {
+		fmt.Printf("Converted config saved to %s\n", outputFile)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goodsun/jobscraper/extract"
+)
+
+// フィールドごとに、試した抽出方法・候補・採用した理由・正規化の変化を表示する
+func printTrace(trace *extract.Trace) {
+	if trace == nil {
+		return
+	}
+	fmt.Println("Extraction trace (* = used):")
+	var untried []string
+	for _, field := range trace.Fields {
+		if len(field.Candidates) == 0 && len(field.Normalization) == 0 {
+			untried = append(untried, field.Field)
+			continue
+		}
+		fmt.Printf("%s = %q\n", field.Field, preview(field.Value, 60))
+		for _, candidate := range field.Candidates {
+			mark := " "
+			if candidate.Used {
+				mark = "*"
+			}
+			value := "(none)"
+			if candidate.Value != "" {
+				value = fmt.Sprintf("%q", preview(candidate.Value, 40))
+			}
+			source := ""
+			if candidate.Source != "" {
+				source = " " + preview(candidate.Source, 50)
+			}
+			fmt.Printf("  %s [%s]%s: %s (%s)\n", mark, candidate.Strategy, source, value, candidate.Reason)
+		}
+		for _, step := range field.Normalization {
+			fmt.Printf("  > [normalize %s]: %q -> %q\n", step.Normalizer, preview(step.Before, 40), preview(step.After, 40))
+		}
+	}
+	if len(untried) > 0 {
+		fmt.Printf("No candidates: %s\n", strings.Join(untried, ", "))
+	}
+}
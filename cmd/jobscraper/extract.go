@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/goodsun/jobscraper"
+	"github.com/goodsun/jobscraper/fetch"
+	"github.com/goodsun/jobscraper/output"
+)
+
+func runExtract(args []string) error {
+	flags := newFlagSet("extract", "[options] <url|file> [output_file]")
+	siteName := flags.String("config", "", "サイト設定を指定（省略時はURLから自動検出）")
+	artifactsDir := flags.String("artifacts", "", "調査用に取得HTML・DOM・ヘッダー・リクエストログを保存するディレクトリ")
+	labelLog := flags.String("label-log", "", "ラベル辞書に未登録のラベルを追記するファイル")
+	render := flags.Bool("render", false, "ヘッドレスChromeでレンダリングしたDOMから抽出")
+	explain := flags.Bool("explain", false, "フィールドごとに試した抽出方法・候補・採用理由・正規化を表示")
+	explainJSON := flags.String("explain-json", "", "--explain の内容をJSONで保存するファイル")
+	searchPath := configDirFlag(flags)
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 1 {
+		flags.Usage()
+		return fmt.Errorf("URL is required")
+	}
+	input, outputFile := positional[0], argAt(positional, 1)
+
+	client := jobscraper.New()
+	client.Configs = searchPath()
+	opts := &jobscraper.Options{Site: *siteName, Render: *render, ArtifactsDir: *artifactsDir, Explain: *explain || *explainJSON != ""}
+	ctx := context.Background()
+
+	var jobs []jobscraper.Job
+	var report *jobscraper.Report
+	if fetch.IsURL(input) {
+		if *render {
+			fmt.Printf("Rendering page: %s\n", input)
+		} else {
+			fmt.Printf("Fetching data from URL: %s\n", input)
+		}
+		jobs, report, err = client.ExtractURL(ctx, input, opts)
+	} else {
+		fmt.Printf("Reading from file: %s\n", input)
+		content, readErr := os.ReadFile(input)
+		if readErr != nil {
+			return fmt.Errorf("reading %s: %v", input, readErr)
+		}
+		jobs, report, err = client.ExtractHTML(ctx, string(content), input, opts)
+	}
+	printReport(report)
+	if err != nil {
+		return err
+	}
+	if *explain {
+		printTrace(report.Trace)
+	}
+	if *explainJSON != "" {
+		if err := output.WriteJSON(report.Trace, *explainJSON); err != nil {
+			return fmt.Errorf("writing trace: %v", err)
+		}
+		fmt.Printf("Saved extraction trace to %s\n", *explainJSON)
+	}
+
+	// 辞書に未登録のラベルを記録
+	if *labelLog != "" {
+		if err := recordUnmappedLabels(*labelLog, report); err != nil {
+			fmt.Printf("Warning: Failed to write label log: %v\n", err)
+		}
+	}
+
+	return writeResult(jobs[0], outputFile, nil)
+}
+
+// 使用した設定と警告を表示する
+func printReport(report *jobscraper.Report) {
+	if report == nil {
+		return
+	}
+	if report.Site != "" {
+		fmt.Printf("Using site configuration: %s\n", report.Site)
+	}
+	if report.ArtifactsDir != "" {
+		fmt.Printf("Saved debug artifacts to %s\n", report.ArtifactsDir)
+	}
+	for _, warning := range report.Warnings {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+}
+
+func argAt(args []string, i int) string {
+	if i < len(args) {
+		return args[i]
+	}
+	return ""
+}
+
+// アーティファクト出力の準備（dir が空なら nil）
+func newArtifacts(dir string, input string) (*fetch.ArtifactWriter, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	artifacts, err := fetch.NewArtifactWriter(dir, input)
+	if err != nil {
+		return nil, fmt.Errorf("creating artifacts directory: %v", err)
+	}
+	fmt.Printf("Saving debug artifacts to %s\n", artifacts.Dir)
+	return artifacts, nil
+}
+
+// URLまたはファイルからHTMLを読む（render なら URL をヘッドレスChromeで開く）
+func fetchPage(input string, artifacts *fetch.ArtifactWriter, render bool) (string, error) {
+	ctx := context.Background()
+	if artifacts != nil {
+		ctx = fetch.WithArtifacts(ctx, artifacts)
+	}
+
+	var htmlContent string
+	var err error
+	switch {
+	case render:
+		fmt.Printf("Rendering page: %s\n", input)
+		htmlContent, err = fetch.Render(ctx, input)
+	case fetch.IsURL(input):
+		fmt.Printf("Fetching data from URL: %s\n", input)
+		htmlContent, err = fetch.Get(ctx, input)
+	default:
+		fmt.Printf("Reading from file: %s\n", input)
+		htmlContent, err = fetch.ReadFile(ctx, input)
+	}
+
+	if artifacts != nil {
+		// 通信はここで終わるので、失敗時も含めてリクエストログを書き出す
+		if fetch.IsURL(input) {
+			if err := artifacts.Close(); err != nil {
+				fmt.Printf("Warning: Failed to write request log: %v\n", err)
+			}
+		}
+		if err == nil && !render {
+			artifacts.WriteDOM(htmlContent)
+		}
+	}
+	return htmlContent, err
+}
+
+// 抽出結果を出力する（出力先が指定されていない場合は標準出力）
+func writeResult(v interface{}, outputFile string, artifacts *fetch.ArtifactWriter) error {
+	if artifacts != nil {
+		artifacts.WriteJSON("result.json", v)
+		if err := artifacts.Err(); err != nil {
+			fmt.Printf("Warning: Failed to write artifacts: %v\n", err)
+		}
+	}
+
+	if err := output.WriteJSON(v, outputFile); err != nil {
+		return fmt.Errorf("writing output: %v", err)
+	}
+	if outputFile != "" {
+		fmt.Printf("Data extracted successfully and saved to %s\n", outputFile)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/goodsun/jobscraper"
+	"github.com/goodsun/jobscraper/config"
+	"github.com/goodsun/jobscraper/extract"
+	"github.com/goodsun/jobscraper/output"
+)
+
+// 未登録ラベルのログ1行分（--label-log）
+type UnmappedLabelRecord struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+	Site  string `json:"site"`
+	URL   string `json:"url"`
+}
+
+// 未登録ラベルをログファイルに追記する（JSON Lines形式）
+func recordUnmappedLabels(logFile string, report *jobscraper.Report) error {
+	var records []interface{}
+	for _, pair := range report.UnmappedLabels {
+		value := []rune(pair.Value)
+		if len(value) > 80 {
+			value = append(value[:80], '…')
+		}
+		records = append(records, UnmappedLabelRecord{Label: pair.Label, Value: string(value), Site: report.Site, URL: report.URL})
+	}
+	return output.AppendJSONLines(logFile, records)
+}
+
+func runListUnmappedLabels(args []string) error {
+	flags := newFlagSet("list-unmapped-labels", "[options] <label_log.jsonl>")
+	searchPath := configDirFlag(flags)
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 1 {
+		flags.Usage()
+		return fmt.Errorf("label log file is required")
+	}
+
+	labels, err := searchPath().LabelDictionary()
+	if err != nil {
+		fmt.Printf("Warning: Could not load label dictionary: %v\n", err)
+		labels = config.EmptyLabelDictionary()
+	}
+	return listUnmappedLabels(positional[0], labels)
+}
+
+// ラベルログを集計して、辞書に未登録のラベルを出現回数順に表示する
+func listUnmappedLabels(logFile string, labels *config.LabelDictionary) error {
+	data, err := ioutil.ReadFile(logFile)
+	if err != nil {
+		return fmt.Errorf("reading label log: %v", err)
+	}
+
+	type labelSummary struct {
+		label  string
+		count  int
+		sites  map[string]bool
+		sample string
+	}
+	summaries := map[string]*labelSummary{}
+	site := &config.SiteConfig{}
+	for _, line := range strings.Split(string(data), "\n") {
+		var record UnmappedLabelRecord
+		if strings.TrimSpace(line) == "" || json.Unmarshal([]byte(line), &record) != nil {
+			continue
+		}
+		// ログ記録後に辞書へ追加されたラベルは除く
+		if len(extract.UnmappedLabels([]extract.LabelValue{{Label: record.Label, Value: record.Value}}, site, labels)) == 0 {
+			continue
+		}
+		summary, ok := summaries[record.Label]
+		if !ok {
+			summary = &labelSummary{label: record.Label, sites: map[string]bool{}, sample: record.Value}
+			summaries[record.Label] = summary
+		}
+		summary.count++
+		summary.sites[record.Site] = true
+	}
+
+	var sorted []*labelSummary
+	for _, summary := range summaries {
+		sorted = append(sorted, summary)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].count != sorted[j].count {
+			return sorted[i].count > sorted[j].count
+		}
+		return sorted[i].label < sorted[j].label
+	})
+
+	fmt.Printf("Unmapped Labels (dictionary version %s)\n", labels.Version)
+	fmt.Println("=====================================")
+	fmt.Println()
+	for _, summary := range sorted {
+		var sites []string
+		for site := range summary.sites {
+			sites = append(sites, site)
+		}
+		sort.Strings(sites)
+		fmt.Printf("%5d  %-20s [%s] 例: %s\n", summary.count, summary.label, strings.Join(sites, ", "), summary.sample)
+	}
+	fmt.Println()
+	fmt.Printf("%d labels. Add them to labels.json to map them to fields.\n", len(sorted))
+	return nil
+}
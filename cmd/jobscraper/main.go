@@ -0,0 +1,130 @@
+// jobscraper は求人ページからデータを抽出するコマンドラインツール。
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/goodsun/jobscraper/config"
+)
+
+// サブコマンドの一覧（help の表示順）
+var commands = []struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}{
+	{"extract", "サイト設定・JSON-LD・ラベル辞書による汎用抽出（推奨）", runExtract},
+	{"scrape-xpath", "XPath設定ファイルによる抽出", runScrapeXPath},
+	{"render", "ヘッドレスChromeでレンダリングしてXPath設定で抽出", runRender},
+	{"list-configs", "利用可能なサイト設定の一覧", runListConfigs},
+	{"show-config", "extends / include を展開したサイト設定を表示", runShowConfig},
+	{"validate-configs", "サイト設定の検証（フィールド名、セレクター・正規表現・XPath、ドメインの重複）", runValidateConfigs},
+	{"convert-config", "旧形式のXPath設定をサイト設定に変換（CSSセレクターの案も表示）", runConvertConfig},
+	{"suggest-config", "見本のページと既知の値からセレクターを推測してサイト設定の下書きを作る", runSuggestConfig},
+	{"shell", "ページを1回読み込み、CSS・XPath・正規表現などを対話的に試す", runShell},
+	{"list-unmapped-labels", "記録した未登録ラベルを出現回数順に表示", runListUnmappedLabels},
+}
+
+func showHelp() {
+	fmt.Println("Job Data Extractor")
+	fmt.Println("==================")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  jobscraper <command> [options] [arguments]")
+	fmt.Println("  jobscraper <command> -h")
+	fmt.Println()
+	fmt.Println("Commands:")
+	for _, command := range commands {
+		fmt.Printf("  %-22s - %s\n", command.name, command.summary)
+	}
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  # 標準出力に表示（自動サイト検出）")
+	fmt.Println("  jobscraper extract https://example.com/job/123")
+	fmt.Println()
+	fmt.Println("  # ファイルに保存（特定のサイト設定を使用）")
+	fmt.Println("  jobscraper extract --config custom-site https://example.com/job/123 output.json")
+	fmt.Println()
+	fmt.Println("  # XPath設定で抽出")
+	fmt.Println("  jobscraper scrape-xpath https://example.com/job/123 configs/kango-oshigoto-xpath.json result.json")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		showHelp()
+		os.Exit(1)
+	}
+
+	name := os.Args[1]
+	if name == "-h" || name == "--help" || name == "help" {
+		showHelp()
+		os.Exit(0)
+	}
+
+	for _, command := range commands {
+		if command.name != name {
+			continue
+		}
+		if err := command.run(os.Args[2:]); err != nil {
+			if err == flag.ErrHelp {
+				os.Exit(0)
+			}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: unknown command %q\n\n", name)
+	showHelp()
+	os.Exit(1)
+}
+
+// オプションと位置引数が混在していても読めるように、位置引数を取り出しながら解析する
+func parseArgs(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func newFlagSet(name string, usage string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: jobscraper %s %s\n\nOptions:\n", name, usage)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// 繰り返し指定できる文字列オプション
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// --config-dir を追加する。返り値の関数は解析後の検索パスを返す
+func configDirFlag(flags *flag.FlagSet) func() config.SearchPath {
+	var dirs stringList
+	flags.Var(&dirs, "config-dir", "設定ディレクトリ（sites/・fragments/・labels.json を置く）。複数指定でき、先のものが優先")
+	return func() config.SearchPath {
+		return config.DefaultSearchPath(dirs...)
+	}
+}
@@ -0,0 +1,524 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+	"github.com/antchfx/htmlquery"
+	"golang.org/x/net/html"
+
+	"github.com/goodsun/jobscraper"
+	"github.com/goodsun/jobscraper/config"
+	"github.com/goodsun/jobscraper/extract"
+	"github.com/goodsun/jobscraper/fetch"
+	"github.com/goodsun/jobscraper/job"
+	"github.com/goodsun/jobscraper/output"
+)
+
+// shell の1セッション（ページは起動時に1回だけ取得する）
+type shellSession struct {
+	input  string
+	render bool
+	search config.SearchPath
+	labels *config.LabelDictionary
+
+	raw  string            // 取得したままのHTML
+	doc  *goquery.Document // サイト設定の encoding で変換したDOM
+	page string            // 変換後のHTML（regex 用）
+
+	site  *config.SiteConfig
+	file  string      // 読み込んだサイト設定のファイル（write の既定の書き込み先）
+	last  shellExpr   // 直前に試した式（save で使う）
+	edits []shellEdit // save したが、まだ write していない変更
+}
+
+type shellExpr struct {
+	kind    string // "css", "xpath", "regex", "jsonld", "state"
+	expr    string
+	matches int // css・xpath で一致した要素の数（save の index の検査に使う）
+}
+
+type shellEdit struct {
+	keys  []string
+	value interface{}
+}
+
+var shellHelp = []struct{ usage, summary string }{
+	{"css <selector>", "CSSセレクターに一致する要素を番号・テキスト・属性付きで表示"},
+	{"xpath <expr>", "XPathに一致するノードを表示"},
+	{"regex <pattern>", "HTML全体での正規表現の一致とグループを表示"},
+	{"jsonld [path]", "JSON-LD JobPosting（パス省略時は全体）"},
+	{"state [path]", "埋め込みJSON（__NEXT_DATA__ など。パス省略時は最上位のキー）"},
+	{"labels", "表形式の見出しと値（ラベル収集の対象）"},
+	{"config [name|file]", "サイト設定を読み込む（省略時は現在の設定と未保存の変更を表示）"},
+	{"extract", "現在のサイト設定で抽出（extract コマンドと同じ処理）"},
+	{"save <field> [index]", "直前の式をフィールドの設定にする（index は何番目の一致を使うか）"},
+	{"write [file]", "save した変更を設定ファイルに書き込む（JSON・YAML）"},
+	{"reload", "ページを取得し直す"},
+	{"help", "このヘルプ"},
+	{"quit", "終了"},
+}
+
+func runShell(args []string) error {
+	flags := newFlagSet("shell", "[options] <url|file>")
+	siteName := flags.String("config", "", "サイト設定を指定（省略時はURLから自動検出）")
+	render := flags.Bool("render", false, "ヘッドレスChromeでレンダリングしたDOMを使う")
+	searchPath := configDirFlag(flags)
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 1 {
+		flags.Usage()
+		return fmt.Errorf("URL or HTML file is required")
+	}
+
+	s := &shellSession{input: positional[0], render: *render, search: searchPath()}
+	if s.labels, err = s.search.LabelDictionary(); err != nil {
+		fmt.Printf("Warning: Could not load label dictionary: %v\n", err)
+		s.labels = config.EmptyLabelDictionary()
+	}
+	if err := s.reload(); err != nil {
+		return err
+	}
+
+	name := *siteName
+	if name == "" && fetch.IsURL(s.input) {
+		name = s.search.Detect(s.input)
+	}
+	s.site = &config.SiteConfig{Name: "default"}
+	if name != "" && name != "default" {
+		if err := s.loadConfig(name); err != nil {
+			return err
+		}
+	} else {
+		s.parse()
+	}
+
+	fmt.Println("Type help for commands, quit to exit.")
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for {
+		fmt.Print("jobscraper> ")
+		if !scanner.Scan() {
+			fmt.Println()
+			break
+		}
+		command, arg := splitCommand(scanner.Text())
+		if command == "" {
+			continue
+		}
+		if command == "quit" || command == "exit" {
+			break
+		}
+		if err := s.run(command, arg); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+	}
+	if len(s.edits) > 0 {
+		fmt.Printf("Warning: %d saved expressions were not written (use write)\n", len(s.edits))
+	}
+	return scanner.Err()
+}
+
+func splitCommand(line string) (string, string) {
+	line = strings.TrimSpace(line)
+	if i := strings.IndexAny(line, " \t"); i >= 0 {
+		return line[:i], strings.TrimSpace(line[i+1:])
+	}
+	return line, ""
+}
+
+func (s *shellSession) run(command string, arg string) error {
+	switch command {
+	case "help":
+		for _, h := range shellHelp {
+			fmt.Printf("  %-22s %s\n", h.usage, h.summary)
+		}
+		return nil
+	case "css":
+		return s.css(arg)
+	case "xpath":
+		return s.xpath(arg)
+	case "regex":
+		return s.regex(arg)
+	case "jsonld":
+		return s.jsonLD(arg)
+	case "state":
+		return s.state(arg)
+	case "labels":
+		for _, pair := range extract.CollectLabels(s.doc, s.site) {
+			fmt.Printf("  %s: %s\n", pair.Label, preview(pair.Value, 80))
+		}
+		return nil
+	case "config":
+		if arg == "" {
+			return s.showConfig()
+		}
+		return s.loadConfig(arg)
+	case "extract":
+		return s.extract()
+	case "save":
+		return s.save(arg)
+	case "write":
+		return s.write(arg)
+	case "reload":
+		if err := s.reload(); err != nil {
+			return err
+		}
+		s.parse()
+		return nil
+	}
+	return fmt.Errorf("unknown command %q (type help)", command)
+}
+
+func (s *shellSession) reload() error {
+	raw, err := fetchPage(s.input, nil, s.render)
+	if err != nil {
+		return err
+	}
+	s.raw = raw
+	return nil
+}
+
+// サイト設定の encoding に合わせてDOMを作り直す
+func (s *shellSession) parse() {
+	s.page = s.raw
+	if s.site != nil && s.site.Encoding != "" {
+		if converted, err := fetch.ConvertEncoding(s.raw, s.site.Encoding); err != nil {
+			fmt.Printf("Warning: failed to convert encoding from %s: %v\n", s.site.Encoding, err)
+		} else {
+			s.page = converted
+		}
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(s.page))
+	if err != nil {
+		// html.Parse はほぼ失敗しないが、念のため空のDOMにする
+		doc = goquery.NewDocumentFromNode(&html.Node{Type: html.DocumentNode})
+	}
+	s.doc = doc
+}
+
+func (s *shellSession) css(selector string) error {
+	if selector == "" {
+		return fmt.Errorf("usage: css <selector>")
+	}
+	// goquery は不正なセレクターでも何も一致しないだけなので、構文は先に調べる
+	if _, err := cascadia.ParseGroup(selector); err != nil {
+		return fmt.Errorf("invalid CSS selector: %v", err)
+	}
+	nodes := s.doc.Find(selector).Nodes
+	s.last = shellExpr{kind: "css", expr: selector, matches: len(nodes)}
+	printNodes(nodes)
+	return nil
+}
+
+func (s *shellSession) xpath(expr string) error {
+	if expr == "" {
+		return fmt.Errorf("usage: xpath <expr>")
+	}
+	nodes, err := htmlquery.QueryAll(s.doc.Nodes[0], expr)
+	if err != nil {
+		return fmt.Errorf("invalid XPath: %v", err)
+	}
+	s.last = shellExpr{kind: "xpath", expr: expr, matches: len(nodes)}
+	printNodes(nodes)
+	return nil
+}
+
+func (s *shellSession) regex(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("usage: regex <pattern>")
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid regex: %v", err)
+	}
+	s.last = shellExpr{kind: "regex", expr: pattern}
+	matches := re.FindAllStringSubmatch(s.page, -1)
+	for i, match := range matches {
+		fmt.Printf("[%d] %s\n", i, preview(match[0], 100))
+		for j, group := range match[1:] {
+			fmt.Printf("    $%d: %s\n", j+1, preview(group, 100))
+		}
+	}
+	fmt.Printf("%d matches\n", len(matches))
+	return nil
+}
+
+func (s *shellSession) jsonLD(path string) error {
+	postings := extract.JobPostings(s.doc)
+	if len(postings) == 0 {
+		fmt.Println("no JSON-LD JobPosting found")
+		return nil
+	}
+	if path != "" {
+		s.last = shellExpr{kind: "jsonld", expr: path}
+	}
+	for i, posting := range postings {
+		fmt.Printf("[%d]\n", i)
+		if path == "" {
+			printJSON(posting)
+			continue
+		}
+		for _, value := range extract.EvalJSONPath(posting, path) {
+			printJSON(value)
+		}
+	}
+	return nil
+}
+
+func (s *shellSession) state(path string) error {
+	state := extract.AppState(s.doc, config.ExtractorConfig{}, nil)
+	if state == nil {
+		fmt.Println("no embedded JSON (__NEXT_DATA__, __NUXT__, ...) found")
+		return nil
+	}
+	if path == "" {
+		if object, ok := state.(map[string]interface{}); ok {
+			var keys []string
+			for key := range object {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			fmt.Println(strings.Join(keys, "\n"))
+			return nil
+		}
+		printJSON(state)
+		return nil
+	}
+	s.last = shellExpr{kind: "state", expr: path}
+	values := extract.EvalJSONPath(state, path)
+	for _, value := range values {
+		printJSON(value)
+	}
+	fmt.Printf("%d values\n", len(values))
+	return nil
+}
+
+func (s *shellSession) loadConfig(name string) error {
+	if len(s.edits) > 0 {
+		fmt.Printf("Warning: discarding %d saved expressions that were not written\n", len(s.edits))
+		s.edits = nil
+	}
+
+	var err error
+	if filepath.Ext(name) != "" || strings.ContainsRune(name, filepath.Separator) {
+		s.site, err = s.search.LoadFile(name)
+		s.file = name
+	} else {
+		s.site, err = s.search.Load(context.Background(), name)
+		if err == nil {
+			if s.file, err = s.search.File(name); err != nil {
+				fmt.Printf("Note: %v\n", err)
+				s.file, err = "", nil
+			}
+		}
+	}
+	if err != nil {
+		s.site = &config.SiteConfig{Name: "default"}
+		s.file = ""
+		s.parse()
+		return fmt.Errorf("loading site config: %v", err)
+	}
+	if s.site.Name == "" {
+		s.site.Name = strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	}
+	fmt.Printf("Using site configuration: %s\n", s.site.Name)
+	s.parse()
+	return nil
+}
+
+func (s *shellSession) showConfig() error {
+	file := s.file
+	if file == "" {
+		file = "(no file; use write <file>)"
+	}
+	fmt.Printf("site: %s\nfile: %s\n", s.site.Name, file)
+	for _, edit := range s.edits {
+		fmt.Printf("  unsaved: %s = %v\n", strings.Join(edit.keys, "."), edit.value)
+	}
+	return nil
+}
+
+// 読み込んだ設定（save した変更を含む）だけを返す Store
+type shellStore struct {
+	site *config.SiteConfig
+}
+
+func (st *shellStore) Detect(url string) string {
+	return st.site.Name
+}
+
+func (st *shellStore) Load(ctx context.Context, name string) (*config.SiteConfig, error) {
+	return st.site, nil
+}
+
+func (s *shellSession) extract() error {
+	client := jobscraper.New()
+	client.Configs = &shellStore{site: s.site}
+	client.Labels = s.labels
+
+	sourceURL := ""
+	if fetch.IsURL(s.input) {
+		sourceURL = s.input
+	}
+	jobs, report, err := client.ExtractHTML(context.Background(), s.raw, sourceURL, nil)
+	if err != nil {
+		return err
+	}
+	for _, data := range jobs {
+		printJSON(data)
+	}
+	for _, warning := range report.Warnings {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+	return nil
+}
+
+func (s *shellSession) save(arg string) error {
+	fields := strings.Fields(arg)
+	if len(fields) < 1 || len(fields) > 2 {
+		return fmt.Errorf("usage: save <field> [index]")
+	}
+	field := fields[0]
+	if !job.IsField(field) {
+		return fmt.Errorf("unknown field %q", field)
+	}
+	index := 0
+	if len(fields) == 2 {
+		var err error
+		if index, err = strconv.Atoi(fields[1]); err != nil || index < 0 {
+			return fmt.Errorf("index must be a number")
+		}
+	}
+	if s.last.kind == "" {
+		return fmt.Errorf("try an expression first (css, xpath, regex, jsonld, state)")
+	}
+	if (s.last.kind == "css" || s.last.kind == "xpath") && index >= s.last.matches {
+		return fmt.Errorf("index %d is out of range: %s matched %d elements", index, s.last.expr, s.last.matches)
+	}
+
+	extractor := config.ExtractorConfig{Value: s.last.expr}
+	switch s.last.kind {
+	case "css":
+		// 既に extractors がある場合はそちらが優先されるので、selectors ではなく extractors を書き換える
+		if _, ok := s.site.Extractors[field]; !ok && index == 0 && extract.IsSelectorField(field) {
+			if s.site.Selectors == nil {
+				s.site.Selectors = map[string]string{}
+			}
+			s.site.Selectors[field] = s.last.expr
+			s.edits = append(s.edits, shellEdit{keys: []string{"selectors", field}, value: s.last.expr})
+			fmt.Printf("selectors.%s = %s\n", field, s.last.expr)
+			return nil
+		}
+		extractor.Type = "selector"
+	case "xpath":
+		extractor.Type = "xpath"
+	case "regex":
+		extractor.Type = "regex"
+	case "jsonld":
+		extractor.Type = "json-ld"
+	case "state":
+		extractor.Type = "app-state"
+	}
+	if index > 0 && extractor.Type != "selector" && extractor.Type != "xpath" {
+		return fmt.Errorf("index is only for css and xpath")
+	}
+	extractor.Index = index
+
+	if s.site.Extractors == nil {
+		s.site.Extractors = map[string]config.ExtractorConfig{}
+	}
+	s.site.Extractors[field] = extractor
+	value := map[string]interface{}{"type": extractor.Type, "value": extractor.Value}
+	if index > 0 {
+		value["index"] = index
+	}
+	s.edits = append(s.edits, shellEdit{keys: []string{"extractors", field}, value: value})
+	fmt.Printf("extractors.%s = %s %s\n", field, extractor.Type, extractor.Value)
+	return nil
+}
+
+func (s *shellSession) write(file string) error {
+	if file == "" {
+		file = s.file
+	}
+	if file == "" {
+		return fmt.Errorf("usage: write <file> (the current config has no editable file)")
+	}
+	if len(s.edits) == 0 {
+		fmt.Println("nothing to write (use save first)")
+		return nil
+	}
+
+	// 新しいファイルには name を先に書く
+	if _, err := os.Stat(file); os.IsNotExist(err) {
+		name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		if err := config.SetConfigValue(file, []string{"name"}, name); err != nil {
+			return err
+		}
+	}
+	for _, edit := range s.edits {
+		if err := config.SetConfigValue(file, edit.keys, edit.value); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("%d changes written to %s\n", len(s.edits), file)
+	s.edits = nil
+	s.file = file
+	return nil
+}
+
+func printNodes(nodes []*html.Node) {
+	for i, node := range nodes {
+		if node.Type == html.ElementNode {
+			fmt.Printf("[%d] %s\n", i, describeElement(node))
+		} else {
+			fmt.Printf("[%d]\n", i)
+		}
+		if text := preview(htmlquery.InnerText(node), 100); text != "" {
+			fmt.Printf("    %s\n", text)
+		}
+	}
+	fmt.Printf("%d matches\n", len(nodes))
+}
+
+// <div class="x" id="y"> の形（属性値は長ければ省略）
+func describeElement(node *html.Node) string {
+	var b strings.Builder
+	b.WriteString("<" + node.Data)
+	for _, attr := range node.Attr {
+		b.WriteString(fmt.Sprintf(" %s=%q", attr.Key, preview(attr.Val, 40)))
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// 空白をまとめて、長ければ max 文字で切る
+func preview(text string, max int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) > max {
+		return string([]rune(text)[:max]) + "…"
+	}
+	return text
+}
+
+func printJSON(v interface{}) {
+	data, err := output.JSON(v)
+	if err != nil {
+		fmt.Printf("%v\n", v)
+		return
+	}
+	fmt.Println(string(data))
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goodsun/jobscraper/config"
+)
+
+func TestShellSaveIndex(t *testing.T) {
+	s := &shellSession{raw: `<html><body><p class="detail">a</p><p class="detail">b</p></body></html>`, site: &config.SiteConfig{}}
+	s.parse()
+
+	tests := []struct {
+		kind string
+		expr string
+		save string
+		err  string // 空ならエラーなし
+	}{
+		{"css", "p.detail", "detail 1", ""},
+		{"css", "p.detail", "detail 2", "index 2 is out of range"},
+		{"css", "p.detail", "detail 5", "index 5 is out of range"},
+		{"css", "p.missing", "detail", "index 0 is out of range"},
+		{"xpath", "//p", "detail 1", ""},
+		{"xpath", "//p", "detail 2", "index 2 is out of range"},
+		{"regex", "<p[^>]*>", "detail 1", "index is only for css and xpath"},
+	}
+	for _, tt := range tests {
+		var err error
+		switch tt.kind {
+		case "css":
+			err = s.css(tt.expr)
+		case "xpath":
+			err = s.xpath(tt.expr)
+		case "regex":
+			err = s.regex(tt.expr)
+		}
+		if err != nil {
+			t.Fatalf("%s %s: %v", tt.kind, tt.expr, err)
+		}
+
+		err = s.save(tt.save)
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("%s %s; save %s: %v", tt.kind, tt.expr, tt.save, err)
+		case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+			t.Errorf("%s %s; save %s: error = %v; want %q", tt.kind, tt.expr, tt.save, err, tt.err)
+		}
+	}
+	if extractor := s.site.Extractors["detail"]; extractor.Type != "xpath" || extractor.Index != 1 {
+		t.Errorf("extractors.detail = %+v; want the last saved xpath with index 1", extractor)
+	}
+}
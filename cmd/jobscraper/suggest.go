@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goodsun/jobscraper/config"
+	"github.com/goodsun/jobscraper/extract"
+	"github.com/goodsun/jobscraper/fetch"
+	"github.com/goodsun/jobscraper/job"
+	"github.com/goodsun/jobscraper/output"
+)
+
+func runSuggestConfig(args []string) error {
+	flags := newFlagSet("suggest-config", "[options] <known_values.json> <page.html|url> [page.html|url ...]")
+	sampleURL := flags.String("url", "", "対象サイトの求人ページのURL（name と domain を推測。ページにURLを渡した場合は省略可）")
+	name := flags.String("name", "", "サイト設定名（省略時はURLまたは --output のファイル名から）")
+	outputFile := flags.String("output", "", "サイト設定の下書きを保存するファイル（省略時は標準出力）")
+	top := flags.Int("top", 3, "フィールドごとに表示する候補の数")
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 2 {
+		flags.Usage()
+		return fmt.Errorf("known values and at least one page are required")
+	}
+
+	known, err := readKnownValues(positional[0])
+	if err != nil {
+		return fmt.Errorf("reading known values: %v", err)
+	}
+	pages := positional[1:]
+	if len(known) > len(pages) {
+		return fmt.Errorf("%d sets of known values for %d pages", len(known), len(pages))
+	}
+
+	ctx := context.Background()
+	samples := make([]extract.Sample, len(pages))
+	for i, page := range pages {
+		content, err := fetch.Input(ctx, page)
+		if err != nil {
+			return fmt.Errorf("reading %s: %v", page, err)
+		}
+		samples[i] = extract.Sample{Name: page, HTML: content}
+		if i < len(known) {
+			samples[i].Known = known[i]
+		}
+		if *sampleURL == "" && fetch.IsURL(page) {
+			*sampleURL = page
+		}
+	}
+
+	suggestions, warnings, err := extract.SuggestSelectors(samples)
+	if err != nil {
+		return err
+	}
+
+	siteName, domain := *name, ""
+	if *sampleURL != "" {
+		inferred, host, err := config.InferSiteName(*sampleURL)
+		if err != nil {
+			return fmt.Errorf("invalid URL: %v", err)
+		}
+		domain = host
+		if siteName == "" {
+			siteName = inferred
+		}
+	}
+	if siteName == "" && *outputFile != "" {
+		siteName = strings.TrimSuffix(filepath.Base(*outputFile), filepath.Ext(*outputFile))
+	}
+	if siteName == "" {
+		siteName = "new-site"
+	}
+
+	// 下書きを標準出力に書く場合もJSONだけになるよう、候補は標準エラーに出す
+	for _, field := range job.FieldNames {
+		ranked, ok := suggestions[field]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "%s:\n", field)
+		for i, suggestion := range ranked {
+			if i >= *top {
+				break
+			}
+			fmt.Fprintf(os.Stderr, "  %.2f  %-5s  matched %d/%d", suggestion.Score, suggestion.Kind, suggestion.Matched, suggestion.Known)
+			if suggestion.Unknown > 0 {
+				fmt.Fprintf(os.Stderr, ", found %d/%d", suggestion.Found, suggestion.Unknown)
+			}
+			fmt.Fprintf(os.Stderr, "\n        css:   %s\n", suggestion.Selector)
+			if suggestion.XPath != "" {
+				fmt.Fprintf(os.Stderr, "        xpath: %s\n", suggestion.XPath)
+			}
+		}
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	if err := output.WriteConfig(extract.DraftConfig(siteName, domain, suggestions), *outputFile); err != nil {
+		return fmt.Errorf("writing output: %v", err)
+	}
+	if *outputFile != "" {
+		fmt.Printf("Draft config saved to %s (check it with validate-configs and extract)\n", *outputFile)
+	}
+	return nil
+}
+
+// 既知の値（ページ1枚なら {"name": "...", ...}、複数なら ページ順の配列）
+func readKnownValues(file string) ([]map[string]string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []map[string]string
+	if err := json.Unmarshal(data, &list); err != nil {
+		var single map[string]string
+		if err := json.Unmarshal(data, &single); err != nil {
+			return nil, err
+		}
+		list = []map[string]string{single}
+	}
+	for _, values := range list {
+		for field := range values {
+			if !job.IsField(field) {
+				return nil, fmt.Errorf("unknown field %q", field)
+			}
+		}
+	}
+	return list, nil
+}
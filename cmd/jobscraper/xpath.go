@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goodsun/jobscraper/config"
+	"github.com/goodsun/jobscraper/extract"
+)
+
+func runScrapeXPath(args []string) error {
+	return runXPath("scrape-xpath", args, false)
+}
+
+func runRender(args []string) error {
+	return runXPath("render", args, true)
+}
+
+// 取得した（render ならヘッドレスChromeでレンダリングした）ページに旧形式のXPath設定を適用する
+func runXPath(name string, args []string, render bool) error {
+	flags := newFlagSet(name, "[options] <url> <xpath_config.json> [output.json]")
+	artifactsDir := flags.String("artifacts", "", "調査用に取得HTML・DOM・ヘッダー・リクエストログ・スクリーンショットを保存するディレクトリ")
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 2 {
+		flags.Usage()
+		return fmt.Errorf("URL and XPath config are required")
+	}
+	url, configFile, outputFile := positional[0], positional[1], argAt(positional, 2)
+
+	// XPath設定を読む
+	xpaths, err := config.LoadXPathConfig(configFile)
+	if err != nil {
+		return fmt.Errorf("reading config file: %v", err)
+	}
+
+	artifacts, err := newArtifacts(*artifactsDir, url)
+	if err != nil {
+		return err
+	}
+
+	htmlContent, err := fetchPage(url, artifacts, render)
+	if err != nil {
+		return fmt.Errorf("scraping data: %v", err)
+	}
+
+	scrapedData, err := extract.XPath(htmlContent, xpaths)
+	if err != nil {
+		return fmt.Errorf("scraping data: %v", err)
+	}
+
+	return writeResult(scrapedData, outputFile, artifacts)
+}
@@ -0,0 +1,359 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+)
+
+// XPath設定から変換したサイト設定
+type Conversion struct {
+	Config    map[string]interface{} // サイト設定（JSONでそのまま書き出せる形）
+	Proposals []CSSProposal          // CSSセレクターに書き換えられたフィールド
+	Warnings  []string
+}
+
+// XPath と同じ要素を選ぶCSSセレクターの案
+type CSSProposal struct {
+	Field    string `json:"field"`
+	XPath    string `json:"xpath"`
+	Selector string `json:"selector"`
+	Attr     string `json:"attr,omitempty"`
+}
+
+type ConvertOptions struct {
+	Name   string
+	Domain string
+	UseCSS bool // 書き換えられたフィールドは xpath ではなく selector 型の extractor にする
+}
+
+// 旧形式のXPath設定を、フィールドごとの xpath 型 extractor を持つサイト設定に変換する。
+// XPath設定の値はサイト設定より優先されていたので、selectors ではなく extractors（JSON-LDより優先）に入れる
+func ConvertXPathConfig(xpaths *XPathConfig, opts ConvertOptions) (*Conversion, error) {
+	data, err := json.Marshal(xpaths)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]string
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	conversion := &Conversion{}
+	extractors := map[string]interface{}{}
+	var names []string
+	for field := range fields {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+
+	for _, field := range names {
+		expr := fields[field]
+		if expr == "" {
+			continue
+		}
+		// 都道府県・市区町村は normalize.Location が住所から取り出し直すので、住所と同じXPathなら不要
+		if (field == "prefecture" || field == "city") && (expr == fields["address"] || expr == fields["area"]) {
+			conversion.Warnings = append(conversion.Warnings, fmt.Sprintf("%s: dropped because it uses the same XPath as the address (the normalizer derives it)", field))
+			continue
+		}
+
+		extractor := map[string]interface{}{"type": "xpath", "value": expr}
+		if selector, attr, ok := XPathToCSS(expr); ok {
+			conversion.Proposals = append(conversion.Proposals, CSSProposal{Field: field, XPath: expr, Selector: selector, Attr: attr})
+			if opts.UseCSS {
+				extractor = map[string]interface{}{"type": "selector", "value": selector}
+				if attr != "" {
+					extractor["attr"] = attr
+				}
+			}
+		}
+		extractors[field] = extractor
+	}
+
+	conversion.Config = map[string]interface{}{
+		"name":       opts.Name,
+		"domain":     opts.Domain,
+		"extractors": extractors,
+	}
+	if opts.Domain == "" {
+		delete(conversion.Config, "domain")
+		conversion.Warnings = append(conversion.Warnings, "domain is not set (give a sample URL to infer it), so the config is not detected automatically")
+	}
+	return conversion, nil
+}
+
+// 「co.jp」などの2階層目のドメイン
+var secondLevelDomains = map[string]bool{
+	"co": true, "or": true, "ne": true, "ac": true, "go": true, "ed": true, "lg": true, "gr": true, "ad": true,
+	"com": true, "net": true, "org": true,
+}
+
+// URLからサイト設定名とドメインを推測する（https://www.nursepower.co.jp/... → nursepower, www.nursepower.co.jp）
+func InferSiteName(rawURL string) (name string, domain string, err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", err
+	}
+	domain = u.Hostname()
+	if domain == "" {
+		return "", "", fmt.Errorf("%q has no host", rawURL)
+	}
+
+	labels := strings.Split(domain, ".")
+	if len(labels) > 1 {
+		labels = labels[:len(labels)-1]
+	}
+	if len(labels) > 1 && secondLevelDomains[labels[len(labels)-1]] {
+		labels = labels[:len(labels)-1]
+	}
+	return labels[len(labels)-1], domain, nil
+}
+
+// XPathの1ステップ
+type xpathStep struct {
+	descendant bool // "//" で始まる
+	text       string
+}
+
+// "/" と "//" で区切る（述語や文字列の中の "/" は区切らない）
+func splitXPathSteps(expr string) ([]xpathStep, bool) {
+	var steps []xpathStep
+	i := 0
+	for i < len(expr) {
+		step := xpathStep{}
+		switch {
+		case strings.HasPrefix(expr[i:], "//"):
+			step.descendant = true
+			i += 2
+		case expr[i] == '/':
+			i++
+		default:
+			return nil, false
+		}
+
+		start, depth := i, 0
+		var quote byte
+		for ; i < len(expr); i++ {
+			c := expr[i]
+			if quote != 0 {
+				if c == quote {
+					quote = 0
+				}
+				continue
+			}
+			switch c {
+			case '\'', '"':
+				quote = c
+			case '[', '(':
+				depth++
+			case ']', ')':
+				depth--
+			}
+			if c == '/' && depth == 0 {
+				break
+			}
+		}
+		step.text = strings.TrimSpace(expr[start:i])
+		if step.text == "" || quote != 0 || depth != 0 {
+			return nil, false
+		}
+		steps = append(steps, step)
+	}
+	return steps, len(steps) > 0
+}
+
+var (
+	xpathNameTest     = regexp.MustCompile(`^([A-Za-z][\w-]*|\*)`)
+	xpathString       = `('[^']*'|"[^"]*")`
+	xpathAttrEquals   = regexp.MustCompile(`^@([A-Za-z][\w-]*)\s*=\s*` + xpathString + `$`)
+	xpathAttrExists   = regexp.MustCompile(`^@([A-Za-z][\w-]*)$`)
+	xpathAttrContains = regexp.MustCompile(`^contains\(\s*@([A-Za-z][\w-]*)\s*,\s*` + xpathString + `\s*\)$`)
+	xpathAttrStarts   = regexp.MustCompile(`^starts-with\(\s*@([A-Za-z][\w-]*)\s*,\s*` + xpathString + `\s*\)$`)
+	xpathTextContains = regexp.MustCompile(`^contains\(\s*(?:\.|text\(\))\s*,\s*` + xpathString + `\s*\)$`)
+	xpathPosition     = regexp.MustCompile(`^[1-9][0-9]*$`)
+	cssIdentifier     = regexp.MustCompile(`^[A-Za-z_][\w-]*$`)
+)
+
+// XPathToCSS は単純なXPathを同じ要素を選ぶCSSセレクター（goquery で使える形）に書き換える。
+// 末尾の /@属性 は attr に、末尾の /text() は省いて返す。書き換えられない式は ok が false。
+//
+// 対応する書き方：子（/）・子孫（//）・following-sibling::（最後の要素のみ）、
+// [@a='v']・[@a]・[contains(@a,'v')]・[starts-with(@a,'v')]・[contains(., 'v')]（:contains）・[n]・[last()]、
+// 述語内の and。contains(text(), 'v') は子孫を含むテキスト全体で比べる :contains になる
+func XPathToCSS(expr string) (selector string, attr string, ok bool) {
+	steps, ok := splitXPathSteps(strings.TrimSpace(expr))
+	if !ok {
+		return "", "", false
+	}
+
+	// 末尾の text() と @属性
+	last := steps[len(steps)-1]
+	switch {
+	case last.text == "text()" && !last.descendant:
+		steps = steps[:len(steps)-1]
+	case strings.HasPrefix(last.text, "@") && !last.descendant && cssIdentifier.MatchString(last.text[1:]):
+		attr = last.text[1:]
+		steps = steps[:len(steps)-1]
+	}
+	if len(steps) == 0 {
+		return "", "", false
+	}
+
+	var css strings.Builder
+	for i, step := range steps {
+		text := step.text
+		sibling := strings.HasPrefix(text, "following-sibling::")
+		if sibling {
+			// 最初に一致した要素を使うので、最後のステップなら「以降の兄弟」で同じ要素になる
+			if step.descendant || i == 0 || i != len(steps)-1 {
+				return "", "", false
+			}
+			text = strings.TrimPrefix(text, "following-sibling::")
+		}
+		text = strings.TrimPrefix(text, "child::")
+
+		compound, ok := cssCompound(text, sibling)
+		if !ok {
+			return "", "", false
+		}
+		switch {
+		case i == 0 && !step.descendant:
+			compound += ":root"
+		case sibling:
+			css.WriteString(" ~ ")
+		case i > 0 && step.descendant:
+			css.WriteString(" ")
+		case i > 0:
+			css.WriteString(" > ")
+		}
+		css.WriteString(compound)
+	}
+
+	selector = css.String()
+	if _, err := cascadia.ParseGroup(selector); err != nil {
+		return "", "", false
+	}
+	return selector, attr, true
+}
+
+// ステップ1つ（要素名と述語）をCSSの複合セレクターにする
+func cssCompound(step string, sibling bool) (string, bool) {
+	name := xpathNameTest.FindString(step)
+	if name == "" {
+		return "", false
+	}
+	predicates, ok := splitPredicates(step[len(name):])
+	if !ok {
+		return "", false
+	}
+
+	var css strings.Builder
+	if name != "*" || len(predicates) == 0 {
+		css.WriteString(name)
+	}
+	for i, predicate := range predicates {
+		if predicate == "1" && sibling && i == len(predicates)-1 {
+			// following-sibling::dd[1] の [1] は「最初に一致したもの」と同じ
+			continue
+		}
+		if xpathPosition.MatchString(predicate) || predicate == "last()" {
+			// 位置の指定は他の条件より前で、要素名がある場合のみ nth-of-type と同じ
+			if i != 0 || name == "*" || sibling {
+				return "", false
+			}
+			if predicate == "last()" {
+				css.WriteString(":last-of-type")
+			} else {
+				css.WriteString(":nth-of-type(" + predicate + ")")
+			}
+			continue
+		}
+		for _, condition := range strings.Split(predicate, " and ") {
+			part, ok := cssCondition(strings.TrimSpace(condition))
+			if !ok {
+				return "", false
+			}
+			css.WriteString(part)
+		}
+	}
+	if css.Len() == 0 {
+		css.WriteString("*")
+	}
+	return css.String(), true
+}
+
+// "[a][b]" → ["a", "b"]
+func splitPredicates(text string) ([]string, bool) {
+	var predicates []string
+	for text != "" {
+		if text[0] != '[' {
+			return nil, false
+		}
+		depth := 0
+		var quote byte
+		end := -1
+		for i := 0; i < len(text) && end < 0; i++ {
+			c := text[i]
+			if quote != 0 {
+				if c == quote {
+					quote = 0
+				}
+				continue
+			}
+			switch c {
+			case '\'', '"':
+				quote = c
+			case '[':
+				depth++
+			case ']':
+				depth--
+				if depth == 0 {
+					end = i
+				}
+			}
+		}
+		if end < 0 {
+			return nil, false
+		}
+		predicates = append(predicates, strings.TrimSpace(text[1:end]))
+		text = strings.TrimSpace(text[end+1:])
+	}
+	return predicates, true
+}
+
+func cssCondition(condition string) (string, bool) {
+	if m := xpathAttrEquals.FindStringSubmatch(condition); m != nil {
+		value := unquoteXPath(m[2])
+		if m[1] == "id" && cssIdentifier.MatchString(value) {
+			return "#" + value, true
+		}
+		return fmt.Sprintf("[%s=%s]", m[1], quoteCSS(value)), true
+	}
+	if m := xpathAttrExists.FindStringSubmatch(condition); m != nil {
+		return "[" + m[1] + "]", true
+	}
+	if m := xpathAttrContains.FindStringSubmatch(condition); m != nil {
+		return fmt.Sprintf("[%s*=%s]", m[1], quoteCSS(unquoteXPath(m[2]))), true
+	}
+	if m := xpathAttrStarts.FindStringSubmatch(condition); m != nil {
+		return fmt.Sprintf("[%s^=%s]", m[1], quoteCSS(unquoteXPath(m[2]))), true
+	}
+	if m := xpathTextContains.FindStringSubmatch(condition); m != nil {
+		return ":contains(" + quoteCSS(unquoteXPath(m[1])) + ")", true
+	}
+	return "", false
+}
+
+func unquoteXPath(s string) string {
+	return s[1 : len(s)-1]
+}
+
+func quoteCSS(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
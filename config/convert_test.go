@@ -0,0 +1,94 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goodsun/jobscraper/output"
+)
+
+func TestXPathToCSS(t *testing.T) {
+	tests := []struct {
+		expr     string
+		selector string
+		attr     string
+		ok       bool
+	}{
+		{"//div[@class='job']/p", "div[class=\"job\"] > p", "", true},
+		{"//h1/text()", "h1", "", true},
+		{"//a[@id='apply']/@href", "a#apply", "href", true},
+		{"//th[contains(., '給与')]/following-sibling::td", "th:contains(\"給与\") ~ td", "", true},
+		{"//ul/li[2]", "ul > li:nth-of-type(2)", "", true},
+		{"//div[position() > 1]", "", "", false},
+		{"count(//p)", "", "", false},
+	}
+	for _, tt := range tests {
+		selector, attr, ok := XPathToCSS(tt.expr)
+		if selector != tt.selector || attr != tt.attr || ok != tt.ok {
+			t.Errorf("XPathToCSS(%q) = %q, %q, %v; want %q, %q, %v", tt.expr, selector, attr, ok, tt.selector, tt.attr, tt.ok)
+		}
+	}
+}
+
+func TestConvertXPathConfig(t *testing.T) {
+	xpaths := &XPathConfig{
+		Name:       "//h1/text()",
+		Price:      "//div[@class='salary']/p",
+		Address:    "//td[@class='address']",
+		Prefecture: "//td[@class='address']",
+		Detail:     "substring(//p, 1, 10)",
+	}
+	conversion, err := ConvertXPathConfig(xpaths, ConvertOptions{Name: "sample", Domain: "www.sample.co.jp", UseCSS: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"name":   "sample",
+		"domain": "www.sample.co.jp",
+		"extractors": map[string]interface{}{
+			"name":    map[string]interface{}{"type": "selector", "value": "h1"},
+			"price":   map[string]interface{}{"type": "selector", "value": "div[class=\"salary\"] > p"},
+			"address": map[string]interface{}{"type": "selector", "value": "td[class=\"address\"]"},
+			// CSSに書き換えられないものは xpath のまま
+			"detail": map[string]interface{}{"type": "xpath", "value": "substring(//p, 1, 10)"},
+		},
+	}
+	if !reflect.DeepEqual(conversion.Config, want) {
+		t.Errorf("Config = %v; want %v", conversion.Config, want)
+	}
+	if len(conversion.Warnings) != 1 || !strings.HasPrefix(conversion.Warnings[0], "prefecture:") {
+		t.Errorf("Warnings = %q; want one for prefecture", conversion.Warnings)
+	}
+
+	// 書き出した設定のセレクターはエスケープしない
+	data, err := output.ConfigJSON(conversion.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"div[class=\"salary\"] > p"`) {
+		t.Errorf("ConfigJSON escaped the selector:\n%s", data)
+	}
+}
+
+func TestInferSiteName(t *testing.T) {
+	tests := []struct {
+		url    string
+		name   string
+		domain string
+	}{
+		{"https://www.nursepower.co.jp/job/1", "nursepower", "www.nursepower.co.jp"},
+		{"https://kyujiner.com/", "kyujiner", "kyujiner.com"},
+		{"https://job.kiracare.jp/detail", "kiracare", "job.kiracare.jp"},
+	}
+	for _, tt := range tests {
+		name, domain, err := InferSiteName(tt.url)
+		if err != nil || name != tt.name || domain != tt.domain {
+			t.Errorf("InferSiteName(%q) = %q, %q, %v; want %q, %q", tt.url, name, domain, err, tt.name, tt.domain)
+		}
+	}
+	if _, _, err := InferSiteName("page.html"); err == nil {
+		t.Error("InferSiteName(page.html): want an error")
+	}
+}
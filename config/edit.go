@@ -0,0 +1,259 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SetConfigValue は設定ファイルの keys（"selectors", "name" のような階層）の位置に value を書き込む。
+// 途中のオブジェクトがなければ作り、ファイルがなければ新しく作る。
+// JSONはキーの順序を、YAMLはコメントも保ったまま書き換える。TOMLは未対応
+func SetConfigValue(path string, keys []string, value interface{}) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("no key to set")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		data, err = setJSONValue(data, keys, value)
+	case ".yaml", ".yml":
+		data, err = setYAMLValue(data, keys, value)
+	default:
+		return fmt.Errorf("%s: only JSON and YAML configs can be edited", path)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// キーの順序を保ったJSONオブジェクト
+type orderedObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedObject() *orderedObject {
+	return &orderedObject{values: map[string]interface{}{}}
+}
+
+func (o *orderedObject) set(key string, value interface{}) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		encodedKey, err := marshalJSON(key)
+		if err != nil {
+			return nil, err
+		}
+		encodedValue, err := marshalJSON(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		buf.Write(encodedValue)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// 正規表現の "<" や "&" をエスケープせずに書く
+func marshalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+func decodeOrdered(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object := newOrderedObject()
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object.set(key.(string), value)
+		}
+		_, err := decoder.Token()
+		return object, err
+	case json.Delim('['):
+		list := []interface{}{}
+		for decoder.More() {
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err := decoder.Token()
+		return list, err
+	}
+	return token, nil
+}
+
+// map は "type"・"value" を先頭に、残りを名前順に並べる（extractors の書き方に合わせる）
+func toOrdered(value interface{}) interface{} {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	var keys []string
+	for key := range object {
+		keys = append(keys, key)
+	}
+	rank := map[string]int{"type": 0, "value": 1}
+	sort.Slice(keys, func(i, j int) bool {
+		ri, iok := rank[keys[i]]
+		rj, jok := rank[keys[j]]
+		if iok != jok {
+			return iok
+		}
+		if iok {
+			return ri < rj
+		}
+		return keys[i] < keys[j]
+	})
+	ordered := newOrderedObject()
+	for _, key := range keys {
+		ordered.set(key, toOrdered(object[key]))
+	}
+	return ordered
+}
+
+func setJSONValue(data []byte, keys []string, value interface{}) ([]byte, error) {
+	root := newOrderedObject()
+	if len(bytes.TrimSpace(data)) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		decoded, err := decodeOrdered(decoder)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := decoder.Token(); err != io.EOF {
+			return nil, fmt.Errorf("unexpected data after the top-level object")
+		}
+		object, ok := decoded.(*orderedObject)
+		if !ok {
+			return nil, fmt.Errorf("top level must be an object")
+		}
+		root = object
+	}
+
+	object := root
+	for _, key := range keys[:len(keys)-1] {
+		child, ok := object.values[key].(*orderedObject)
+		if !ok {
+			child = newOrderedObject()
+			object.set(key, child)
+		}
+		object = child
+	}
+	object.set(keys[len(keys)-1], toOrdered(value))
+
+	encoded, err := marshalJSON(root)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, encoded, "", "    "); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+func setYAMLValue(data []byte, keys []string, value interface{}) ([]byte, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	if document.Kind == 0 {
+		document = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	mapping := document.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("top level must be a mapping")
+	}
+
+	var valueNode yaml.Node
+	if err := valueNode.Encode(value); err != nil {
+		return nil, err
+	}
+
+	for i, key := range keys {
+		var found *yaml.Node
+		for j := 0; j+1 < len(mapping.Content); j += 2 {
+			if mapping.Content[j].Value == key {
+				found = mapping.Content[j+1]
+				break
+			}
+		}
+		if i == len(keys)-1 {
+			if found != nil {
+				// 値の前後に書かれたコメントは残す
+				valueNode.HeadComment, valueNode.LineComment, valueNode.FootComment = found.HeadComment, found.LineComment, found.FootComment
+				*found = valueNode
+			} else {
+				mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &valueNode)
+			}
+			break
+		}
+		if found == nil || found.Kind != yaml.MappingNode {
+			child := &yaml.Node{Kind: yaml.MappingNode}
+			if found != nil {
+				*found = *child
+				child = found
+			} else {
+				mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, child)
+			}
+			found = child
+		}
+		mapping = found
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
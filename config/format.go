@@ -0,0 +1,202 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// 設定ファイルとして読む拡張子（同じ名前のファイルが複数あればこの順で優先）
+var ConfigExtensions = []string{".json", ".yaml", ".yml", ".toml"}
+
+func isConfigFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, known := range ConfigExtensions {
+		if ext == known {
+			return true
+		}
+	}
+	return false
+}
+
+// 拡張子を除いた設定名
+func configName(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+func readConfig(file location) (map[string]interface{}, error) {
+	data, err := file.readFile()
+	if err != nil {
+		return nil, err
+	}
+	return decodeConfig(file.name, data)
+}
+
+// 拡張子に応じてJSON・YAML・TOMLを読み、JSONと同じ型（map[string]interface{}、float64 など）にそろえる
+func decodeConfig(path string, data []byte) (map[string]interface{}, error) {
+	var value interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+	case ".toml":
+		var table map[string]interface{}
+		if _, err := toml.Decode(string(data), &table); err != nil {
+			return nil, err
+		}
+		value = table
+	default:
+		var config map[string]interface{}
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, err
+		}
+		return config, nil
+	}
+
+	// JSONを経由して数値や入れ子の型をJSONで読んだときと同じにする
+	converted, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var config map[string]interface{}
+	if err := json.Unmarshal(converted, &config); err != nil {
+		return nil, fmt.Errorf("top level must be a mapping")
+	}
+	return config, nil
+}
+
+var yamlErrorLine = regexp.MustCompile(`line (\d+)`)
+
+// 読み込みエラーの行番号（分からなければ 0）
+func errorLine(err error, data []byte) int {
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		return bytes.Count(data[:e.Offset], []byte("\n")) + 1
+	case *json.UnmarshalTypeError:
+		return bytes.Count(data[:e.Offset], []byte("\n")) + 1
+	case toml.ParseError:
+		return e.Position.Line
+	case *toml.ParseError:
+		return e.Position.Line
+	}
+	if matches := yamlErrorLine.FindStringSubmatch(err.Error()); matches != nil {
+		line, _ := strconv.Atoi(matches[1])
+		return line
+	}
+	return 0
+}
+
+// JSON Pointer ごとの行番号
+func configLines(path string, data []byte) map[string]int {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return yamlLines(data)
+	case ".toml":
+		return tomlLines(data)
+	}
+	return jsonLines(data)
+}
+
+func yamlLines(data []byte) map[string]int {
+	lines := map[string]int{}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return lines
+	}
+
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if _, ok := lines[path]; !ok {
+			lines[path] = node.Line
+		}
+		switch node.Kind {
+		case yaml.DocumentNode:
+			for _, child := range node.Content {
+				walk(child, path)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				child := path + "/" + escapePointer(node.Content[i].Value)
+				lines[child] = node.Content[i].Line
+				walk(node.Content[i+1], child)
+			}
+		case yaml.SequenceNode:
+			for i, item := range node.Content {
+				walk(item, path+"/"+strconv.Itoa(i))
+			}
+		}
+	}
+	walk(&root, "")
+	return lines
+}
+
+var tomlTable = regexp.MustCompile(`^\s*(\[\[?)\s*([^\]]+?)\s*\]\]?`)
+var tomlKey = regexp.MustCompile(`^\s*((?:"[^"]*"|'[^']*'|[A-Za-z0-9_$-]+)(?:\s*\.\s*(?:"[^"]*"|'[^']*'|[A-Za-z0-9_$-]+))*)\s*=`)
+
+// TOMLはパーサーがキーの位置を返さないので、テーブル見出しと "key =" の行から求める
+func tomlLines(data []byte) map[string]int {
+	lines := map[string]int{}
+	table := ""
+	arrayCounts := map[string]int{}
+
+	for i, line := range strings.Split(string(data), "\n") {
+		number := i + 1
+		if matches := tomlTable.FindStringSubmatch(line); matches != nil {
+			table = tomlPointer(matches[2])
+			if matches[1] == "[[" {
+				index := arrayCounts[table]
+				arrayCounts[table]++
+				if _, ok := lines[table]; !ok {
+					lines[table] = number
+				}
+				table += "/" + strconv.Itoa(index)
+			}
+			lines[table] = number
+			continue
+		}
+		if matches := tomlKey.FindStringSubmatch(line); matches != nil {
+			lines[table+tomlPointer(matches[1])] = number
+		}
+	}
+	return lines
+}
+
+// `a."b.c".d` → "/a/b.c/d"
+func tomlPointer(key string) string {
+	var pointer strings.Builder
+	for _, part := range splitTOMLKey(key) {
+		part = strings.TrimSpace(part)
+		part = strings.Trim(part, `"'`)
+		pointer.WriteString("/" + escapePointer(part))
+	}
+	return pointer.String()
+}
+
+func splitTOMLKey(key string) []string {
+	var parts []string
+	var quote rune
+	start := 0
+	for i, c := range key {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '.':
+			parts = append(parts, key[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, key[start:])
+}
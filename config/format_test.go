@@ -0,0 +1,140 @@
+package config
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestDecodeConfig(t *testing.T) {
+	want := map[string]interface{}{
+		"name":      "sample",
+		"selectors": map[string]interface{}{"name": "h1 > span"},
+		"patterns":  map[string]interface{}{"salary": `月給\s*(\d+)`},
+		"extractors": map[string]interface{}{
+			"position": map[string]interface{}{"type": "selector", "value": "th:contains('役職') + td", "index": float64(1)},
+		},
+		"label_pairs": []interface{}{map[string]interface{}{"head": "dt", "value": "dd"}},
+		"harvest":     false,
+	}
+	tests := []struct {
+		path string
+		data string
+	}{
+		{"sample.json", `{
+    "name": "sample",
+    "selectors": {"name": "h1 > span"},
+    "patterns": {"salary": "月給\\s*(\\d+)"},
+    "extractors": {"position": {"type": "selector", "value": "th:contains('役職') + td", "index": 1}},
+    "label_pairs": [{"head": "dt", "value": "dd"}],
+    "harvest": false
+}`},
+		{"sample.yaml", `name: sample
+selectors:
+  name: h1 > span
+patterns:
+  salary: '月給\s*(\d+)'
+extractors:
+  position: {type: selector, value: "th:contains('役職') + td", index: 1}
+label_pairs:
+  - {head: dt, value: dd}
+harvest: false
+`},
+		{"sample.toml", `name = "sample"
+harvest = false
+
+[selectors]
+name = "h1 > span"
+
+[patterns]
+salary = '月給\s*(\d+)'
+
+[extractors.position]
+type = "selector"
+value = "th:contains('役職') + td"
+index = 1
+
+[[label_pairs]]
+head = "dt"
+value = "dd"
+`},
+	}
+	for _, tt := range tests {
+		got, err := decodeConfig(tt.path, []byte(tt.data))
+		if err != nil {
+			t.Errorf("decodeConfig(%s): %v", tt.path, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("decodeConfig(%s) = %v; want %v", tt.path, got, want)
+		}
+	}
+}
+
+func TestDecodeConfigErrors(t *testing.T) {
+	tests := []struct {
+		path string
+		data string
+		line int // 読み込みエラーの行番号（0 なら行番号なし）
+	}{
+		{"broken.json", "{\n    \"name\": \"a\",\n    \"selectors\": {\n}", 4},
+		{"broken.yaml", "name: a\nselectors:\n\tname: h1\n", 3},
+		{"broken.toml", "name = \"a\"\n\n[selectors]\nname = h1\n", 4},
+		// 最上位がマッピングでないもの
+		{"list.yaml", "- name: a\n", 0},
+	}
+	for _, tt := range tests {
+		_, err := decodeConfig(tt.path, []byte(tt.data))
+		if err == nil {
+			t.Errorf("decodeConfig(%s): want an error", tt.path)
+			continue
+		}
+		if line := errorLine(err, []byte(tt.data)); line != tt.line {
+			t.Errorf("errorLine(%s: %v) = %d; want %d", tt.path, err, line, tt.line)
+		}
+	}
+}
+
+func TestConfigLines(t *testing.T) {
+	tests := []struct {
+		path    string
+		data    string
+		pointer string
+		line    int
+	}{
+		{"a.json", "{\n    \"name\": \"a\",\n    \"selectors\": {\n        \"price\": \".salary\"\n    }\n}", "/selectors/price", 4},
+		{"a.yaml", "name: a\nselectors:\n  name: h1\n  price: .salary\n", "/selectors/price", 4},
+		{"a.toml", "name = \"a\"\n\n[selectors]\nname = \"h1\"\nprice = \".salary\"\n", "/selectors/price", 5},
+	}
+	for _, tt := range tests {
+		if line := configLines(tt.path, []byte(tt.data))[tt.pointer]; line != tt.line {
+			t.Errorf("configLines(%s)[%s] = %d; want %d", tt.path, tt.pointer, line, tt.line)
+		}
+	}
+}
+
+func TestLoadPrefersJSONOverYAML(t *testing.T) {
+	search := SearchPath{{Name: "format", FS: fstest.MapFS{
+		"sites/site.yaml": {Data: []byte("name: site\nselectors:\n  name: h1.yaml\n")},
+		"sites/site.json": {Data: []byte(`{"name": "site", "selectors": {"name": "h1.json"}}`)},
+		"sites/only.toml": {Data: []byte("name = \"only\"\n[selectors]\nname = \"h1.toml\"\n")},
+	}}}
+	tests := []struct {
+		name     string
+		selector string
+	}{
+		{"site", "h1.json"},
+		{"only", "h1.toml"},
+	}
+	for _, tt := range tests {
+		site, err := search.Load(context.Background(), tt.name)
+		if err != nil {
+			t.Errorf("Load(%q): %v", tt.name, err)
+			continue
+		}
+		if got := site.Selectors["name"]; got != tt.selector {
+			t.Errorf("Load(%q).Selectors[name] = %q; want %q", tt.name, got, tt.selector)
+		}
+	}
+}
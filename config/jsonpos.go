@@ -0,0 +1,78 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// JSON Pointer ごとの行番号（オブジェクトのメンバーはキーの行）
+func jsonLines(data []byte) map[string]int {
+	lines := map[string]int{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	lineAt := func(offset int64) int {
+		// 区切り文字と空白を飛ばした次のトークンの行
+		for offset < int64(len(data)) {
+			c := data[offset]
+			if c != ' ' && c != '\t' && c != '\r' && c != '\n' && c != ',' && c != ':' {
+				break
+			}
+			offset++
+		}
+		return bytes.Count(data[:offset], []byte("\n")) + 1
+	}
+
+	var walk func(path string) error
+	walk = func(path string) error {
+		offset := dec.InputOffset()
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if _, ok := lines[path]; !ok {
+			lines[path] = lineAt(offset)
+		}
+		switch token {
+		case json.Delim('{'):
+			for dec.More() {
+				offset := dec.InputOffset()
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				child := path + "/" + escapePointer(key.(string))
+				lines[child] = lineAt(offset)
+				if err := walk(child); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				if err := walk(path + "/" + strconv.Itoa(i)); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		}
+		return err
+	}
+	walk("")
+	return lines
+}
+
+// path かその親に最も近い行（見つからなければ 0）
+func lineFor(lines map[string]int, path string) int {
+	for {
+		if line, ok := lines[path]; ok {
+			return line
+		}
+		i := strings.LastIndex(path, "/")
+		if i < 0 {
+			return 0
+		}
+		path = path[:i]
+	}
+}
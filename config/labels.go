@@ -0,0 +1,127 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/goodsun/jobscraper/job"
+)
+
+// ラベルの表記ゆれ辞書（configs/labels.json）
+type LabelDictionary struct {
+	Version     string               `json:"version"`
+	Description string               `json:"description"`
+	Fields      map[string]LabelRule `json:"fields"`
+}
+
+// フィールド1つ分の割り当てルール
+type LabelRule struct {
+	Labels  []string `json:"labels"`  // 完全一致・部分一致で割り当てるラベル（先頭ほど優先）
+	Exact   []string `json:"exact"`   // 完全一致の場合のみ割り当てるラベル
+	Exclude []string `json:"exclude"` // これを含むラベルは割り当てない
+}
+
+// 空の辞書（サイト別の labels 設定のみ有効）
+func EmptyLabelDictionary() *LabelDictionary {
+	return &LabelDictionary{Fields: map[string]LabelRule{}}
+}
+
+func LoadLabelDictionary(path string) (*LabelDictionary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseLabelDictionary(data)
+}
+
+func parseLabelDictionary(data []byte) (*LabelDictionary, error) {
+	var dictionary LabelDictionary
+	if err := json.Unmarshal(data, &dictionary); err != nil {
+		return nil, err
+	}
+	for field := range dictionary.Fields {
+		if !job.IsField(field) {
+			return nil, fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return &dictionary, nil
+}
+
+var labelTrimChars = "：:・■□●○◆◇▼▶★☆※【】[]「」()（）"
+
+// 比較用にラベルを正規化する（空白・記号・末尾のコロンを除去）
+func NormalizeLabel(label string) string {
+	label = strings.Join(strings.Fields(label), "")
+	return strings.Trim(label, labelTrimChars)
+}
+
+func normalizeLabels(labels []string) []string {
+	normalized := make([]string, 0, len(labels))
+	for _, label := range labels {
+		normalized = append(normalized, NormalizeLabel(label))
+	}
+	return normalized
+}
+
+// 辞書にサイト別の labels 設定を重ねたルールを作る（サイト別設定が最優先）
+func (d *LabelDictionary) Rules(site *SiteConfig) map[string]LabelRule {
+	rules := map[string]LabelRule{}
+	for field, rule := range d.Fields {
+		rules[field] = LabelRule{
+			Labels:  normalizeLabels(rule.Labels),
+			Exact:   normalizeLabels(rule.Exact),
+			Exclude: normalizeLabels(rule.Exclude),
+		}
+	}
+	for label, field := range site.Labels {
+		if field != "" {
+			rule := rules[field]
+			rule.Labels = append([]string{NormalizeLabel(label)}, rule.Labels...)
+			rules[field] = rule
+		}
+	}
+	return rules
+}
+
+// サイト別設定で無視するラベル
+func (c *SiteConfig) IgnoredLabels() map[string]bool {
+	ignored := map[string]bool{}
+	for label, field := range c.Labels {
+		if field == "" {
+			ignored[NormalizeLabel(label)] = true
+		}
+	}
+	return ignored
+}
+
+func (rule LabelRule) excludes(label string) bool {
+	for _, exclude := range rule.Exclude {
+		if strings.Contains(label, exclude) {
+			return true
+		}
+	}
+	return false
+}
+
+// ラベルが割り当てられる場合、何番目の候補に一致したかを返す
+func (rule LabelRule) Match(label string, exact bool) (int, bool) {
+	if rule.excludes(label) {
+		return 0, false
+	}
+	for i, candidate := range rule.Labels {
+		if label == candidate || (!exact && strings.Contains(label, candidate)) {
+			return i, true
+		}
+	}
+	if exact {
+		for i, candidate := range rule.Exact {
+			if label == candidate {
+				return len(rule.Labels) + i, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,191 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+	"strings"
+)
+
+// 検索パスの <siteName>.json（.yaml / .yml / .toml も可）を extends / include を展開して読む
+func (p SearchPath) Resolve(siteName string) (map[string]interface{}, error) {
+	found, err := p.find(sitesDir, siteName)
+	if err != nil {
+		return nil, err
+	}
+	return (&resolver{search: p}).resolve(found)
+}
+
+// 設定ファイルを extends / include を展開したJSONオブジェクトとして読む
+//
+// 展開の順序とマージのルール：
+//   - extends の設定 → include の断片（書いた順）→ 自分自身 の順に重ね、後のものが優先
+//   - オブジェクト（selectors、patterns、extractors など）はキーごとに再帰的にマージ
+//   - 配列（label_pairs、plugins など）は後ろに連結（同じ要素は1つにまとめる）
+//   - null を書いたキーは継承した値ごと削除
+//   - name と domain はサイト固有なので継承しない
+//
+// 名前での指定は、extends は sites/<name>.json、include は fragments/<name>.json を検索パスの前から順に探す
+// （.yaml / .yml / .toml も可）。検索パスの外のファイルでは、まずそのファイルと同じディレクトリ
+// （include は ../fragments）を探す。"/" を含むか拡張子がある場合は、書かれたファイルからの相対パスとして読む
+func (p SearchPath) ResolveFile(path string) (map[string]interface{}, error) {
+	return (&resolver{search: p}).resolve(fileLocation(path))
+}
+
+// 検索パスを使わずに設定ファイルを展開する（名前での参照はファイルからの相対位置のみ）
+func ResolveFile(path string) (map[string]interface{}, error) {
+	return SearchPath(nil).ResolveFile(path)
+}
+
+type resolver struct {
+	search SearchPath
+	stack  []string
+}
+
+func (r *resolver) resolve(file location) (map[string]interface{}, error) {
+	key := file.key()
+	for _, visited := range r.stack {
+		if visited == key {
+			return nil, fmt.Errorf("circular extends/include: %s", strings.Join(append(r.stack, key), " -> "))
+		}
+	}
+	r.stack = append(r.stack, key)
+	defer func() { r.stack = r.stack[:len(r.stack)-1] }()
+
+	raw, err := readConfig(file)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+
+	merged := map[string]interface{}{}
+	if extends, ok := raw["extends"]; ok {
+		name, ok := extends.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: extends must be a string", file)
+		}
+		baseFile, err := r.reference(file, name, sitesDir)
+		if err != nil {
+			return nil, err
+		}
+		base, err := r.resolve(baseFile)
+		if err != nil {
+			return nil, err
+		}
+		mergeConfig(merged, inheritable(base))
+	}
+
+	includes, err := stringList(raw["include"])
+	if err != nil {
+		return nil, fmt.Errorf("%s: include %v", file, err)
+	}
+	for _, name := range includes {
+		fragmentFile, err := r.reference(file, name, fragmentsDir)
+		if err != nil {
+			return nil, err
+		}
+		fragment, err := r.resolve(fragmentFile)
+		if err != nil {
+			return nil, err
+		}
+		mergeConfig(merged, inheritable(fragment))
+	}
+
+	delete(raw, "extends")
+	delete(raw, "include")
+	mergeConfig(merged, raw)
+	return merged, nil
+}
+
+// 参照されたファイル（名前なら dir（sites または fragments）の <name>.json / .yaml / .yml / .toml）
+func (r *resolver) reference(from location, ref string, dir string) (location, error) {
+	if strings.Contains(ref, "/") || isConfigFile(ref) {
+		return from.relative(ref), nil
+	}
+	if from.source.Name == "file" {
+		sibling := "."
+		if dir == fragmentsDir {
+			sibling = path.Join("..", fragmentsDir)
+		}
+		if found, ok := from.findConfig(sibling, ref); ok {
+			return found, nil
+		}
+		if len(r.search) == 0 {
+			return location{}, fmt.Errorf("%s: no %s file", from.relative(path.Join(sibling, ref)), strings.Join(ConfigExtensions, ", "))
+		}
+	}
+	return r.search.find(dir, ref)
+}
+
+// "a" と ["a", "b"] のどちらの書き方も受け付ける
+func stringList(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		var list []string
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("must be a string or an array of strings")
+			}
+			list = append(list, s)
+		}
+		return list, nil
+	}
+	return nil, fmt.Errorf("must be a string or an array of strings")
+}
+
+// 継承元からサイト固有の項目を除く
+func inheritable(config map[string]interface{}) map[string]interface{} {
+	delete(config, "name")
+	delete(config, "domain")
+	return config
+}
+
+func mergeConfig(dst map[string]interface{}, src map[string]interface{}) {
+	for key, value := range src {
+		if value == nil {
+			delete(dst, key)
+			continue
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if existing, ok := dst[key].(map[string]interface{}); ok {
+				mergeConfig(existing, v)
+				continue
+			}
+			object := map[string]interface{}{}
+			mergeConfig(object, v)
+			dst[key] = object
+		case []interface{}:
+			existing, _ := dst[key].([]interface{})
+			dst[key] = appendUnique(existing, v)
+		default:
+			dst[key] = value
+		}
+	}
+}
+
+func appendUnique(list []interface{}, items []interface{}) []interface{} {
+	result := append([]interface{}{}, list...)
+	for _, item := range items {
+		duplicate := false
+		for _, existing := range result {
+			if reflect.DeepEqual(existing, item) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			result = append(result, item)
+		}
+	}
+	return result
+}
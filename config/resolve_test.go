@@ -0,0 +1,84 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestResolve(t *testing.T) {
+	search := SearchPath{{Name: "resolve", FS: fstest.MapFS{
+		"sites/base.json": {Data: []byte(`{
+    "name": "base",
+    "domain": "base.example.jp",
+    "selectors": {"name": "h1", "price": ".salary", "area": ".area"},
+    "patterns": {"salary": "月給(\\d+)"},
+    "label_pairs": [{"head": "dt", "value": "dd"}]
+}`)},
+		"fragments/table.json": {Data: []byte(`{
+    "selectors": {"price": "th:contains('給与') + td"},
+    "label_pairs": [{"head": "dt", "value": "dd"}, {"head": "th", "value": "td"}],
+    "plugins": ["table"]
+}`)},
+		"fragments/address.json": {Data: []byte(`{"selectors": {"area": "th:contains('勤務地') + td"}}`)},
+		"sites/child.json": {Data: []byte(`{
+    "name": "child",
+    "extends": "base",
+    "include": ["table", "address"],
+    "selectors": {"name": "h1.title"},
+    "patterns": null
+}`)},
+		"sites/loop-a.json": {Data: []byte(`{"name": "loop-a", "extends": "loop-b"}`)},
+		"sites/loop-b.json": {Data: []byte(`{"name": "loop-b", "extends": "loop-a"}`)},
+		"sites/bad.json":    {Data: []byte(`{"name": "bad", "include": [1]}`)},
+	}}}
+
+	resolved, err := search.Resolve("child")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		key  string
+		want interface{}
+	}{
+		{"name", "child"},
+		// domain はサイト固有なので継承しない
+		{"domain", nil},
+		// extends → include の順 → 自分自身 の順に重ね、後のものが優先
+		{"selectors", map[string]interface{}{
+			"name":  "h1.title",
+			"price": "th:contains('給与') + td",
+			"area":  "th:contains('勤務地') + td",
+		}},
+		// null は継承した値ごと削除
+		{"patterns", nil},
+		// 配列は連結し、同じ要素は1つにまとめる
+		{"label_pairs", []interface{}{
+			map[string]interface{}{"head": "dt", "value": "dd"},
+			map[string]interface{}{"head": "th", "value": "td"},
+		}},
+		{"plugins", []interface{}{"table"}},
+		{"extends", nil},
+		{"include", nil},
+	}
+	for _, tt := range tests {
+		if got := resolved[tt.key]; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("resolved[%q] = %v; want %v", tt.key, got, tt.want)
+		}
+	}
+
+	errorTests := []struct {
+		name string
+		want string
+	}{
+		{"loop-a", "circular extends/include"},
+		{"bad", "include must be a string or an array of strings"},
+		{"missing", "no .json"},
+	}
+	for _, tt := range errorTests {
+		if _, err := search.Resolve(tt.name); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Resolve(%q) error = %v; want it to contain %q", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// サイト設定のJSON Schema（configs/sites/*.json の "$schema" に指定できる）
+//
+//go:embed schema/site-config.schema.json
+var SiteConfigSchema []byte
+
+// scrape-xpath / render 用XPath設定のJSON Schema
+//
+//go:embed schema/xpath-config.schema.json
+var XPathConfigSchema []byte
+
+// スキーマ違反1件分（Path は "/selectors/name" のようなJSON Pointer）
+type SchemaError struct {
+	Path    string
+	Message string
+}
+
+// validate-configs が使う範囲の JSON Schema（type, enum, properties, additionalProperties,
+// propertyNames, items, required, $ref）で value を検査する
+func ValidateSchema(schema []byte, value interface{}) ([]SchemaError, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return nil, fmt.Errorf("parsing schema: %v", err)
+	}
+	v := &schemaValidator{root: root}
+	v.validate(root, value, "")
+	sort.SliceStable(v.errors, func(i, j int) bool { return v.errors[i].Path < v.errors[j].Path })
+	return v.errors, nil
+}
+
+type schemaValidator struct {
+	root   map[string]interface{}
+	errors []SchemaError
+}
+
+func (v *schemaValidator) errorf(path string, format string, args ...interface{}) {
+	v.errors = append(v.errors, SchemaError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// "#/$defs/field" を解決する
+func (v *schemaValidator) ref(ref string) map[string]interface{} {
+	var node interface{} = v.root
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		object, ok := node.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		node = object[part]
+	}
+	schema, _ := node.(map[string]interface{})
+	return schema
+}
+
+func (v *schemaValidator) validate(schema map[string]interface{}, value interface{}, path string) bool {
+	if schema == nil {
+		return true
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		if !v.validate(v.ref(ref), value, path) {
+			return false
+		}
+	}
+
+	if types, ok := schema["type"]; ok && !schemaTypeMatches(types, value) {
+		v.errorf(path, "expected %s, got %s", schemaTypeNames(types), jsonTypeName(value))
+		return false
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			if reflect.DeepEqual(allowed, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.errorf(path, "%s is not one of the allowed values", jsonString(value))
+			return false
+		}
+	}
+
+	valid := true
+	switch value := value.(type) {
+	case map[string]interface{}:
+		properties, _ := schema["properties"].(map[string]interface{})
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				if key, ok := name.(string); ok {
+					if _, ok := value[key]; !ok {
+						v.errorf(path, "missing required property %q", key)
+						valid = false
+					}
+				}
+			}
+		}
+		for _, key := range sortedKeys(value) {
+			child := path + "/" + escapePointer(key)
+			if names, ok := schema["propertyNames"].(map[string]interface{}); ok {
+				if !v.validateName(names, key, child) {
+					valid = false
+					continue
+				}
+			}
+			if property, ok := properties[key].(map[string]interface{}); ok {
+				valid = v.validate(property, value[key], child) && valid
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					v.errorf(child, "unknown property %q", key)
+					valid = false
+				}
+			case map[string]interface{}:
+				valid = v.validate(additional, value[key], child) && valid
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range value {
+				valid = v.validate(items, item, fmt.Sprintf("%s/%d", path, i)) && valid
+			}
+		}
+	}
+	return valid
+}
+
+// propertyNames はキー名の誤りとして報告する
+func (v *schemaValidator) validateName(schema map[string]interface{}, name string, path string) bool {
+	inner := &schemaValidator{root: v.root}
+	if inner.validate(schema, name, path) {
+		return true
+	}
+	v.errorf(path, "unknown field %q", name)
+	return false
+}
+
+func schemaTypeMatches(types interface{}, value interface{}) bool {
+	switch t := types.(type) {
+	case string:
+		return jsonTypeIs(t, value)
+	case []interface{}:
+		for _, name := range t {
+			if s, ok := name.(string); ok && jsonTypeIs(s, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func jsonTypeIs(name string, value interface{}) bool {
+	switch name {
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == float64(int64(n))
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	}
+	return jsonTypeName(value) == name
+}
+
+func schemaTypeNames(types interface{}) string {
+	switch t := types.(type) {
+	case []interface{}:
+		var names []string
+		for _, name := range t {
+			names = append(names, fmt.Sprint(name))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(types)
+}
+
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func jsonString(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,432 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/goodsun/jobscraper/configs"
+)
+
+// 設定ディレクトリを追加する環境変数（複数ならパス区切り文字 ":"（Windowsは ";"）で並べる）
+const ConfigPathEnv = "JOBSCRAPER_CONFIG_PATH"
+
+// 設定ディレクトリ内の置き場所
+const (
+	sitesDir     = "sites"
+	fragmentsDir = "fragments"
+	labelsFile   = "labels.json"
+	welfareFile  = "welfare.json"
+)
+
+// Source は設定の置き場所1つ。sites/（サイト設定）・fragments/（共有部品）・labels.json（ラベル辞書）・
+// welfare.json（福利厚生のタグ辞書）を持つ
+type Source struct {
+	Name string // 由来（"--config-dir"、"JOBSCRAPER_CONFIG_PATH"、"user"、"embedded" など）
+	Dir  string // 設定ディレクトリ
+	FS   fs.FS  // ディレクトリの代わりに読むファイルシステム（埋め込みの標準設定など）
+}
+
+func (s Source) String() string {
+	if s.FS != nil {
+		return s.Name
+	}
+	return fmt.Sprintf("%s (%s)", s.Dir, s.Name)
+}
+
+// 置き場所内の相対パス（スラッシュ区切り）のファイル
+func (s Source) file(rel string) location {
+	if s.FS != nil {
+		return location{source: s, name: rel}
+	}
+	return location{source: s, name: path.Join(filepath.ToSlash(s.Dir), rel)}
+}
+
+// 置き場所内の dir にある name の設定ファイル
+func (s Source) findConfig(dir string, name string) (location, bool) {
+	for _, ext := range ConfigExtensions {
+		if candidate := s.file(path.Join(dir, name+ext)); candidate.exists() {
+			return candidate, true
+		}
+	}
+	return location{}, false
+}
+
+// 置き場所内のディレクトリにある設定ファイル（名前順）
+func (s Source) files(dir string) []location {
+	var entries []fs.DirEntry
+	if s.FS != nil {
+		entries, _ = fs.ReadDir(s.FS, dir)
+	} else {
+		entries, _ = os.ReadDir(filepath.Join(s.Dir, dir))
+	}
+
+	var files []location
+	for _, entry := range entries {
+		if !entry.IsDir() && isConfigFile(entry.Name()) {
+			files = append(files, s.file(path.Join(dir, entry.Name())))
+		}
+	}
+	return files
+}
+
+// 設定ファイル1つの場所
+type location struct {
+	source Source
+	name   string // Source.FS 内のパス、または OS のパス（いずれもスラッシュ区切り）
+}
+
+// 任意の場所にある設定ファイル（validate-configs や show-config で直接指定されたもの）
+func fileLocation(file string) location {
+	return location{source: Source{Name: "file", Dir: filepath.Dir(file)}, name: filepath.ToSlash(file)}
+}
+
+func (l location) String() string {
+	if l.source.FS != nil {
+		return l.source.Name + ":" + l.name
+	}
+	return filepath.FromSlash(l.name)
+}
+
+// 循環参照の検出に使う同一性のキー
+func (l location) key() string {
+	if l.source.FS != nil {
+		return l.String()
+	}
+	if abs, err := filepath.Abs(filepath.FromSlash(l.name)); err == nil {
+		return abs
+	}
+	return l.name
+}
+
+func (l location) readFile() ([]byte, error) {
+	if l.source.FS != nil {
+		return fs.ReadFile(l.source.FS, l.name)
+	}
+	return os.ReadFile(filepath.FromSlash(l.name))
+}
+
+func (l location) exists() bool {
+	var err error
+	if l.source.FS != nil {
+		_, err = fs.Stat(l.source.FS, l.name)
+	} else {
+		_, err = os.Stat(filepath.FromSlash(l.name))
+	}
+	return err == nil
+}
+
+// このファイルからの相対パスのファイル
+func (l location) relative(rel string) location {
+	return location{source: l.source, name: path.Join(path.Dir(l.name), rel)}
+}
+
+// dir の name.json / name.yaml / name.yml / name.toml のうち最初に見つかったもの
+func (l location) findConfig(dir string, name string) (location, bool) {
+	for _, ext := range ConfigExtensions {
+		if candidate := l.relative(path.Join(dir, name+ext)); candidate.exists() {
+			return candidate, true
+		}
+	}
+	return location{}, false
+}
+
+// SearchPath は設定の置き場所を優先順に並べたもの。
+// 同じ名前のサイト設定・共有部品・ラベル辞書は、前の置き場所にあるものが後ろのものを上書きする
+type SearchPath []Source
+
+var _ Store = SearchPath(nil)
+
+// 標準の検索パス：
+//   - dirs（--config-dir で指定したディレクトリ）
+//   - 環境変数 JOBSCRAPER_CONFIG_PATH のディレクトリ
+//   - ユーザー設定ディレクトリ（$XDG_CONFIG_HOME/jobscraper、未設定なら ~/.config/jobscraper）
+//   - カレントディレクトリの configs/（リポジトリ内で実行した場合）
+//   - バイナリに埋め込んだ標準設定
+//
+// 存在しないディレクトリは、--config-dir と環境変数で指定したもの以外は除く
+func DefaultSearchPath(dirs ...string) SearchPath {
+	var p SearchPath
+	for _, dir := range dirs {
+		p = append(p, Source{Name: "--config-dir", Dir: dir})
+	}
+	for _, dir := range filepath.SplitList(os.Getenv(ConfigPathEnv)) {
+		if dir != "" {
+			p = append(p, Source{Name: ConfigPathEnv, Dir: dir})
+		}
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		if dir := filepath.Join(dir, "jobscraper"); isDir(dir) {
+			p = append(p, Source{Name: "user", Dir: dir})
+		}
+	}
+	if isDir("configs") {
+		p = append(p, Source{Name: "working directory", Dir: "configs"})
+	}
+	return append(p, Source{Name: "embedded", FS: configs.FS})
+}
+
+func isDir(dir string) bool {
+	info, err := os.Stat(dir)
+	return err == nil && info.IsDir()
+}
+
+func (p SearchPath) String() string {
+	var sources []string
+	for _, source := range p {
+		sources = append(sources, source.String())
+	}
+	return strings.Join(sources, ", ")
+}
+
+// dir（sites または fragments）にある name の設定ファイルを、前の置き場所から順に探す
+func (p SearchPath) find(dir string, name string) (location, error) {
+	for _, source := range p {
+		if found, ok := source.findConfig(dir, name); ok {
+			return found, nil
+		}
+	}
+	return location{}, fmt.Errorf("%s/%s: no %s file in %s", dir, name, strings.Join(ConfigExtensions, ", "), p)
+}
+
+// Detect は URL に対応するサイト設定名を返す。
+// URLのホストを各サイト設定の domain と先に照合し（ホストが domain そのものか、そのサブドメイン。
+// 複数が当てはまれば長い domain、前の置き場所の順）、どれにも当てはまらなければ組み込みの判定を使う。
+// サンプル用のドメイン（example.com など）の設定は照合しない
+func (p SearchPath) Detect(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil && u.Hostname() != "" {
+		host := strings.ToLower(u.Hostname())
+		for _, entry := range p.domains() {
+			if host == entry.domain || strings.HasSuffix(host, "."+entry.domain) {
+				return entry.name
+			}
+		}
+	}
+	return DetectSite(rawURL)
+}
+
+// サンプル用に予約されたドメイン（RFC 2606）
+func isExampleDomain(domain string) bool {
+	for _, example := range []string{"example", "example.com", "example.net", "example.org"} {
+		if domain == example || strings.HasSuffix(domain, "."+example) {
+			return true
+		}
+	}
+	return false
+}
+
+// サイト設定の domain 1つ分
+type domainEntry struct {
+	domain string
+	name   string
+}
+
+// 検索パスごとの domain の索引（Detect のたびに全設定を読み直さない）。
+// ディレクトリの設定ファイルが追加・変更・削除されたら作り直す（FS の置き場所は変わらないものとして名前で区別する）
+type domainIndex struct {
+	stamp   string
+	entries []domainEntry
+}
+
+var (
+	domainIndexMu sync.Mutex
+	domainIndexes = map[string]domainIndex{}
+)
+
+// 検索パス上のサイト設定の domain を照合する順（長い domain、前の置き場所、名前の順）に並べたもの
+func (p SearchPath) domains() []domainEntry {
+	key, stamp := p.String(), p.stamp()
+	domainIndexMu.Lock()
+	defer domainIndexMu.Unlock()
+	if index, ok := domainIndexes[key]; ok && index.stamp == stamp {
+		return index.entries
+	}
+
+	priority := map[string]int{}
+	for i := len(p) - 1; i >= 0; i-- {
+		priority[p[i].String()] = i
+	}
+	entries, _ := p.List()
+	var index []domainEntry
+	sources := map[string]int{}
+	for _, entry := range entries {
+		if entry.Config == nil {
+			continue
+		}
+		domain := strings.ToLower(entry.Config.Domain)
+		if domain != "" && !isExampleDomain(domain) {
+			index = append(index, domainEntry{domain: domain, name: entry.Name})
+			sources[entry.Name] = priority[entry.Source.String()]
+		}
+	}
+	sort.SliceStable(index, func(i, j int) bool {
+		if len(index[i].domain) != len(index[j].domain) {
+			return len(index[i].domain) > len(index[j].domain)
+		}
+		return sources[index[i].name] < sources[index[j].name]
+	})
+	domainIndexes[key] = domainIndex{stamp: stamp, entries: index}
+	return index
+}
+
+// ディレクトリの設定ファイル（sites/・fragments/）の名前・サイズ・更新日時を並べたもの（埋め込みの標準設定は変わらないので除く）
+func (p SearchPath) stamp() string {
+	var b strings.Builder
+	for _, source := range p {
+		if source.FS != nil {
+			continue
+		}
+		for _, dir := range []string{sitesDir, fragmentsDir} {
+			for _, file := range source.files(dir) {
+				if info, err := os.Stat(filepath.FromSlash(file.name)); err == nil {
+					fmt.Fprintf(&b, "%s\t%d\t%d\n", file.name, info.Size(), info.ModTime().UnixNano())
+				}
+			}
+		}
+	}
+	return b.String()
+}
+
+func (p SearchPath) Load(ctx context.Context, name string) (*SiteConfig, error) {
+	found, err := p.find(sitesDir, name)
+	if err != nil {
+		return nil, err
+	}
+	return p.load(found)
+}
+
+// 任意の場所の設定ファイルを読む（extends / include の名前は検索パスからも探す）
+func (p SearchPath) LoadFile(configPath string) (*SiteConfig, error) {
+	return p.load(fileLocation(configPath))
+}
+
+// 設定一覧の1件分（読み込みに失敗した場合は Err が入る）
+type Entry struct {
+	Name      string
+	Path      string
+	Source    Source   // 使われる設定の置き場所
+	Overrides []Source // この設定で上書きされている、後ろの置き場所
+	Config    *SiteConfig
+	Err       error
+}
+
+// 検索パス上のサイト設定を名前順に返す（同じ名前は前の置き場所のものを使う）
+func (p SearchPath) List() ([]Entry, error) {
+	var entries []Entry
+	index := map[string]int{}
+	for _, source := range p {
+		seen := map[string]bool{}
+		for _, file := range source.files(sitesDir) {
+			name := configName(file.name)
+			if seen[name] {
+				// 同じ置き場所で形式違いのファイルは Load と同じく先の拡張子を使う
+				continue
+			}
+			seen[name] = true
+
+			if i, ok := index[name]; ok {
+				entries[i].Overrides = append(entries[i].Overrides, source)
+				continue
+			}
+			found, _ := source.findConfig(sitesDir, name)
+			entry := Entry{Name: name, Path: found.String(), Source: source}
+			entry.Config, entry.Err = p.load(found)
+			index[name] = len(entries)
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	return entries, nil
+}
+
+// 最初に見つかった labels.json を読む
+func (p SearchPath) LabelDictionary() (*LabelDictionary, error) {
+	for _, source := range p {
+		file := source.file(labelsFile)
+		if !file.exists() {
+			continue
+		}
+		data, err := file.readFile()
+		if err != nil {
+			return nil, err
+		}
+		dictionary, err := parseLabelDictionary(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", file, err)
+		}
+		return dictionary, nil
+	}
+	return nil, notFoundError{labelsFile, p}
+}
+
+// 最初に見つかった welfare.json を読む
+func (p SearchPath) WelfareDictionary() (*WelfareDictionary, error) {
+	for _, source := range p {
+		file := source.file(welfareFile)
+		if !file.exists() {
+			continue
+		}
+		data, err := file.readFile()
+		if err != nil {
+			return nil, err
+		}
+		dictionary, err := parseWelfareDictionary(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", file, err)
+		}
+		return dictionary, nil
+	}
+	return nil, notFoundError{welfareFile, p}
+}
+
+// 検索パスのどこにも辞書がない（errors.Is(err, fs.ErrNotExist) で見分けられる）
+type notFoundError struct {
+	file string
+	path SearchPath
+}
+
+func (e notFoundError) Error() string {
+	return fmt.Sprintf("%s not found in %s", e.file, e.path)
+}
+
+func (e notFoundError) Is(target error) bool {
+	return target == fs.ErrNotExist
+}
+
+// 検証対象の設定ファイル。ディレクトリの置き場所がなければ埋め込みの標準設定
+func (p SearchPath) configFiles() []location {
+	var files []location
+	for _, embedded := range []bool{false, true} {
+		for _, source := range p {
+			if (source.FS != nil) != embedded {
+				continue
+			}
+			files = append(files, source.files(sitesDir)...)
+			files = append(files, source.files(fragmentsDir)...)
+		}
+		if len(files) > 0 {
+			break
+		}
+	}
+	return files
+}
+
+// 検索パス上の name のサイト設定ファイルのパス（書き換え用。埋め込みの標準設定はエラー）
+func (p SearchPath) File(name string) (string, error) {
+	found, err := p.find(sitesDir, name)
+	if err != nil {
+		return "", err
+	}
+	if found.source.FS != nil {
+		return "", fmt.Errorf("%s is built in; copy it into a config directory (see list-configs) to edit it", found)
+	}
+	return found.String(), nil
+}
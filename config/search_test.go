@@ -0,0 +1,122 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+// サイト設定1つだけの置き場所
+func siteFS(name string, domain string) fstest.MapFS {
+	return fstest.MapFS{
+		"sites/" + name + ".json": {Data: []byte(`{"name": "` + name + `", "domain": "` + domain + `", "selectors": {"name": "h1"}}`)},
+	}
+}
+
+func TestDetect(t *testing.T) {
+	// 置き場所は名前で索引を分けるので、テストごとに別の名前にする
+	user := siteFS("my-nursepower", "www.nursepower.co.jp")
+	user["sites/jobs.json"] = &fstest.MapFile{Data: []byte(`{"name": "jobs", "domain": "jobs.example.jp"}`)}
+	user["sites/jobs-wide.json"] = &fstest.MapFile{Data: []byte(`{"name": "jobs-wide", "domain": "example.jp"}`)}
+	search := SearchPath{
+		{Name: "detect-user", FS: user},
+		{Name: "detect-embedded", FS: fstest.MapFS{
+			"sites/example-site.json": {Data: []byte(`{"name": "example-site", "domain": "example.com"}`)},
+			"sites/nursepower.json":   {Data: []byte(`{"name": "nursepower", "domain": "www.nursepower.co.jp"}`)},
+		}},
+	}
+	tests := []struct {
+		url  string
+		want string
+	}{
+		// 設定の domain が組み込みの判定より先で、同じ domain なら前の置き場所
+		{"https://www.nursepower.co.jp/job/1", "my-nursepower"},
+		// 長い domain が先
+		{"https://jobs.example.jp/1", "jobs"},
+		{"https://www.example.jp/1", "jobs-wide"},
+		// ホストの一部が一致するだけでは当てはめない
+		{"https://notexample.jp/", "default"},
+		{"https://example.jp.evil.test/", "default"},
+		// サンプル用のドメインは照合しない
+		{"https://example.com/job/123", "default"},
+		{"https://notexample.com/", "default"},
+		// 設定に当てはまらなければ組み込みの判定
+		{"https://kyujiner.com/job/1", "kyujiner"},
+		{"https://nursepower.co.jp/job/1", "nursepower"},
+		{"testdata/page.html", "default"},
+	}
+	for _, tt := range tests {
+		if got := search.Detect(tt.url); got != tt.want {
+			t.Errorf("Detect(%q) = %q; want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestDetectSeesNewConfigs(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "sites"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	search := SearchPath{{Name: "detect-dir", Dir: dir}}
+	if got := search.Detect("https://jobs.local.jp/1"); got != "default" {
+		t.Fatalf("Detect before adding a config = %q; want default", got)
+	}
+
+	file := filepath.Join(dir, "sites", "local.json")
+	if err := os.WriteFile(file, []byte(`{"name": "local", "domain": "jobs.local.jp"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := search.Detect("https://jobs.local.jp/1"); got != "local" {
+		t.Errorf("Detect after adding a config = %q; want local", got)
+	}
+
+	if err := os.WriteFile(file, []byte(`{"name": "local", "domain": "other.local.jp"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := search.Detect("https://jobs.local.jp/1"); got != "default" {
+		t.Errorf("Detect after editing the domain = %q; want default", got)
+	}
+}
+
+func TestSearchPathPrecedence(t *testing.T) {
+	search := SearchPath{
+		{Name: "first", FS: fstest.MapFS{
+			"sites/site.json": {Data: []byte(`{"name": "site", "selectors": {"name": "h1.first"}}`)},
+		}},
+		{Name: "second", FS: fstest.MapFS{
+			"sites/site.yaml":  {Data: []byte("name: site\nselectors:\n  name: h1.second\n")},
+			"sites/other.json": {Data: []byte(`{"name": "other", "selectors": {"name": "h1.other"}}`)},
+		}},
+	}
+	tests := []struct {
+		name     string
+		selector string
+	}{
+		{"site", "h1.first"},
+		{"other", "h1.other"},
+	}
+	for _, tt := range tests {
+		site, err := search.Load(context.Background(), tt.name)
+		if err != nil {
+			t.Errorf("Load(%q): %v", tt.name, err)
+			continue
+		}
+		if got := site.Selectors["name"]; got != tt.selector {
+			t.Errorf("Load(%q).Selectors[name] = %q; want %q", tt.name, got, tt.selector)
+		}
+	}
+
+	entries, err := search.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[1].Name != "site" || entries[1].Source.Name != "first" || len(entries[1].Overrides) != 1 {
+		t.Errorf("List() = %+v; want site from first overriding second", entries)
+	}
+
+	if _, err := search.Load(context.Background(), "missing"); err == nil {
+		t.Error("Load(missing): want an error")
+	}
+}
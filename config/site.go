@@ -0,0 +1,110 @@
+// Package config はサイト設定・ラベル辞書・XPath設定の読み込みを行う。
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// サイト別の抽出ルール
+type SiteConfig struct {
+	Name       string                     `json:"name"`
+	Domain     string                     `json:"domain"`
+	Encoding   string                     `json:"encoding"`
+	Patterns   map[string]string          `json:"patterns"`
+	Selectors  map[string]string          `json:"selectors"`
+	Extractors map[string]ExtractorConfig `json:"extractors"`
+	Labels     map[string]string          `json:"labels"`      // ラベル → フィールド（辞書への追加・上書き、"" で無視）
+	LabelPairs []LabelPair                `json:"label_pairs"` // th/td、dt/dd 以外の見出し/値の組
+	Harvest    *bool                      `json:"harvest"`     // false でラベル収集を無効化（省略時は有効）
+	Plugins    []string                   `json:"plugins"`     // 抽出後に適用するプラグイン（設定名と同名のものは自動で適用）
+}
+
+// 見出し要素と、その後ろの兄弟要素のうち値を持つ要素のセレクター
+type LabelPair struct {
+	Head  string `json:"head"`
+	Value string `json:"value"`
+}
+
+type ExtractorConfig struct {
+	Type  string `json:"type"`  // "selector", "xpath", "regex", "json-ld", "app-state"
+	Value string `json:"value"` // CSS selector, XPath, regex pattern, JSON path, etc.
+	Attr  string `json:"attr"`  // attribute to extract (text, href, etc.)
+	Index int    `json:"index"` // which match to use (default 0)
+
+	// app-state: 埋め込みJSONの探し方（すべて省略時は __NEXT_DATA__ / __NUXT__ 等を自動検出）
+	ScriptID string `json:"script_id,omitempty"` // <script id="..."> のid
+	Variable string `json:"variable,omitempty"`  // "window.__INITIAL_STATE__" のような代入先
+	Pattern  string `json:"pattern,omitempty"`   // JSON部分を1番目のグループで捕捉する正規表現
+}
+
+// ラベル収集が有効かどうか（省略時は有効）
+func (c *SiteConfig) HarvestEnabled() bool {
+	return c.Harvest == nil || *c.Harvest
+}
+
+func DetectSite(url string) string {
+	if strings.Contains(url, "kirara-support.jp") {
+		return "kirara-support"
+	} else if strings.Contains(url, "kyujiner.com") {
+		return "kyujiner"
+	} else if strings.Contains(url, "cme-pharmacist.jp") {
+		return "cme-pharmacist"
+	} else if strings.Contains(url, "th-agent.jp") {
+		return "th-agent"
+	} else if strings.Contains(url, "nursepower.co.jp") {
+		return "nursepower"
+	} else if strings.Contains(url, "nursejj.com") {
+		return "nursejj"
+	} else if strings.Contains(url, "yakumatch.com") {
+		return "yakumatch"
+	} else if strings.Contains(url, "supernurse.co.jp") {
+		return "supernurse"
+	} else if strings.Contains(url, "mc-nurse.net") {
+		return "mc-nurse"
+	} else if strings.Contains(url, "benesse-mcm.jp") {
+		return "benesse-mcm"
+	} else if strings.Contains(url, "kango-oshigoto.jp") {
+		return "kango-oshigoto"
+	} else if strings.Contains(url, "job.kiracare.jp") {
+		return "kiracare"
+	} else if strings.Contains(url, "pharmacareer.jp") {
+		return "pharmacareer"
+	} else if strings.Contains(url, "nurse-step.com") {
+		return "nurse-step"
+	}
+	// 他のサイトの判定を追加
+	return "default"
+}
+
+// Store はサイト設定の取得元
+type Store interface {
+	// URLに対応するサイト設定名（該当なしは "default"）
+	Detect(url string) string
+	Load(ctx context.Context, name string) (*SiteConfig, error)
+}
+
+// 検索パスを使わずに設定ファイルを読む（extends / include はファイルからの相対位置のみ）
+func LoadFile(configPath string) (*SiteConfig, error) {
+	return SearchPath(nil).LoadFile(configPath)
+}
+
+// extends / include を展開して読み込む
+func (p SearchPath) load(file location) (*SiteConfig, error) {
+	resolved, err := (&resolver{search: p}).resolve(file)
+	if err != nil {
+		return nil, err
+	}
+	configData, err := json.Marshal(resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	var config SiteConfig
+	if err := json.Unmarshal(configData, &config); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
@@ -0,0 +1,340 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+	"github.com/antchfx/xpath"
+
+	"github.com/goodsun/jobscraper/job"
+)
+
+// 検証で見つかった問題1件分
+type Issue struct {
+	File     string
+	Line     int    // 0 なら行を特定できない
+	Severity string // "error" または "warning"
+	Message  string
+}
+
+func (i Issue) String() string {
+	if i.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s: %s", i.File, i.Line, i.Severity, i.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", i.File, i.Severity, i.Message)
+}
+
+// Validator はサイト設定・共有部品・XPath設定を検証する
+type Validator struct {
+	Search          SearchPath              // extends / include の名前を探す検索パス
+	HasPlugin       func(name string) bool  // nil ならプラグイン名は検査しない
+	IsSelectorField func(field string) bool // nil なら selectors のフィールド名は検査しない
+}
+
+// 検証中のファイル
+type configFile struct {
+	path     string
+	fragment bool
+	lines    map[string]int
+	issues   []Issue
+}
+
+func (f *configFile) add(severity string, pointer string, format string, args ...interface{}) {
+	f.issues = append(f.issues, Issue{
+		File:     f.path,
+		Line:     lineFor(f.lines, pointer),
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// files を検証する（ドメインの重複はこの中で比較する）
+func (v *Validator) Validate(files []string) []Issue {
+	var locations []location
+	for _, file := range files {
+		locations = append(locations, fileLocation(file))
+	}
+	return v.validate(locations)
+}
+
+// 検索パス上のディレクトリにある全設定ファイルを検証する（ディレクトリがなければ埋め込みの標準設定）。
+// 検証したファイル数も返す
+func (v *Validator) ValidateSearchPath() ([]Issue, int) {
+	files := v.Search.configFiles()
+	return v.validate(files), len(files)
+}
+
+func (v *Validator) validate(files []location) []Issue {
+	var issues []Issue
+	domains := map[string][]string{}
+	domainLines := map[string]int{}
+
+	for _, location := range files {
+		path := location.String()
+		// 同じ名前で形式違いのファイルは、読まれない方を報告
+		if isConfigFile(location.name) {
+			if used, ok := location.findConfig(".", configName(location.name)); ok && used.key() != location.key() {
+				issues = append(issues, Issue{File: path, Severity: "warning", Message: fmt.Sprintf("ignored because %s has the same name", used)})
+			}
+		}
+
+		file, raw := v.validateFile(location)
+		issues = append(issues, file.issues...)
+		if domain, ok := raw["domain"].(string); ok && domain != "" && !file.fragment {
+			domains[domain] = append(domains[domain], path)
+			domainLines[path] = lineFor(file.lines, "/domain")
+		}
+	}
+
+	// 同じドメインの設定が複数あると自動検出がどれを選ぶか分からない
+	var names []string
+	for domain := range domains {
+		names = append(names, domain)
+	}
+	sort.Strings(names)
+	for _, domain := range names {
+		paths := domains[domain]
+		if len(paths) < 2 {
+			continue
+		}
+		for _, path := range paths {
+			var others []string
+			for _, other := range paths {
+				if other != path {
+					others = append(others, other)
+				}
+			}
+			issues = append(issues, Issue{
+				File:     path,
+				Line:     domainLines[path],
+				Severity: "warning",
+				Message:  fmt.Sprintf("domain %q is also used by %s", domain, strings.Join(others, ", ")),
+			})
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].File != issues[j].File {
+			return issues[i].File < issues[j].File
+		}
+		return issues[i].Line < issues[j].Line
+	})
+	return issues
+}
+
+func isFragment(name string) bool {
+	return path.Base(path.Dir(name)) == fragmentsDir
+}
+
+// 旧形式のXPath設定（selectors などを持たず、値が "/" や "(" で始まる）
+func isXPathConfig(raw map[string]interface{}) bool {
+	if len(raw) == 0 {
+		return false
+	}
+	for key := range raw {
+		if key == "selectors" || key == "extractors" || key == "domain" || key == "extends" || key == "include" {
+			return false
+		}
+	}
+	for _, value := range raw {
+		if s, ok := value.(string); ok && (strings.HasPrefix(s, "/") || strings.HasPrefix(s, "(")) {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *Validator) validateFile(location location) (*configFile, map[string]interface{}) {
+	path := location.String()
+	file := &configFile{path: path, fragment: isFragment(location.name), lines: map[string]int{}}
+
+	data, err := location.readFile()
+	if err != nil {
+		file.add("error", "", "%v", err)
+		return file, nil
+	}
+
+	raw, err := decodeConfig(location.name, data)
+	if err != nil {
+		file.issues = append(file.issues, Issue{File: path, Line: errorLine(err, data), Severity: "error", Message: err.Error()})
+		return file, nil
+	}
+	file.lines = configLines(location.name, data)
+
+	if isXPathConfig(raw) {
+		v.validateXPathConfig(file, raw)
+		return file, raw
+	}
+
+	schemaErrors, err := ValidateSchema(SiteConfigSchema, raw)
+	if err != nil {
+		file.add("error", "", "%v", err)
+		return file, raw
+	}
+	for _, schemaErr := range schemaErrors {
+		file.add("error", schemaErr.Path, "%s: %s", displayPointer(schemaErr.Path), schemaErr.Message)
+	}
+
+	// extends / include を展開できるか
+	resolved, err := (&resolver{search: v.Search}).resolve(location)
+	if err != nil {
+		pointer := ""
+		if _, ok := raw["include"]; ok {
+			pointer = "/include"
+		}
+		if _, ok := raw["extends"]; ok {
+			pointer = "/extends"
+		}
+		file.add("error", pointer, "%v", err)
+		resolved = raw
+	}
+
+	v.validateSiteConfig(file, raw, resolved)
+	return file, raw
+}
+
+func (v *Validator) validateSiteConfig(file *configFile, raw map[string]interface{}, resolved map[string]interface{}) {
+	fragment := file.fragment
+
+	if name, ok := raw["name"].(string); ok && !fragment {
+		if base := configName(file.path); name != base {
+			file.add("warning", "/name", "name %q does not match the file name %q (plugins and --config use the file name)", name, base)
+		}
+	}
+
+	if selectors, ok := raw["selectors"].(map[string]interface{}); ok {
+		for _, field := range sortedKeys(selectors) {
+			pointer := "/selectors/" + escapePointer(field)
+			// selectors で取れないフィールドは抽出時に黙って無視されるので、extractors に書いてもらう
+			// （JobData にないフィールドはスキーマのエラーにまかせる）
+			if v.IsSelectorField != nil && job.IsField(field) && !v.IsSelectorField(field) {
+				file.add("error", pointer, "selectors.%s is not read by extract (use extractors.%s with \"type\": \"selector\")", field, field)
+				continue
+			}
+			if selector, ok := selectors[field].(string); ok && selector != "" {
+				checkSelector(file, pointer, selector)
+			}
+		}
+	}
+
+	if pairs, ok := raw["label_pairs"].([]interface{}); ok {
+		for i, pair := range pairs {
+			if object, ok := pair.(map[string]interface{}); ok {
+				for _, key := range []string{"head", "value"} {
+					if selector, ok := object[key].(string); ok && selector != "" {
+						checkSelector(file, fmt.Sprintf("/label_pairs/%d/%s", i, key), selector)
+					}
+				}
+			}
+		}
+	}
+
+	patterns, _ := resolved["patterns"].(map[string]interface{})
+	if own, ok := raw["patterns"].(map[string]interface{}); ok {
+		for _, name := range sortedKeys(own) {
+			if pattern, ok := own[name].(string); ok {
+				checkRegex(file, "/patterns/"+escapePointer(name), pattern)
+			}
+		}
+	}
+
+	used := map[string]bool{}
+	extractors, _ := resolved["extractors"].(map[string]interface{})
+	for _, field := range sortedKeys(extractors) {
+		extractor, ok := extractors[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pointer := "/extractors/" + escapePointer(field)
+		value, _ := extractor["value"].(string)
+		switch extractor["type"] {
+		case "selector":
+			checkSelector(file, pointer+"/value", value)
+		case "xpath":
+			checkXPath(file, pointer+"/value", value)
+		case "regex":
+			if strings.HasPrefix(value, "@") {
+				name := value[1:]
+				used[name] = true
+				if _, ok := patterns[name]; !ok {
+					file.add("error", pointer+"/value", "extractors.%s refers to undefined pattern %q", field, name)
+				}
+			} else {
+				checkRegex(file, pointer+"/value", value)
+			}
+		case "app-state":
+			if pattern, ok := extractor["pattern"].(string); ok && pattern != "" {
+				checkRegex(file, pointer+"/pattern", pattern)
+			}
+		}
+	}
+
+	// 共有部品のパターンは使う側で参照されるので、サイト設定自身に書かれたものだけ見る
+	if own, ok := raw["patterns"].(map[string]interface{}); ok && !fragment {
+		for _, name := range sortedKeys(own) {
+			if !used[name] {
+				file.add("warning", "/patterns/"+escapePointer(name), "pattern %q is not used by any regex extractor (refer to it with \"value\": \"@%s\")", name, name)
+			}
+		}
+	}
+
+	if v.HasPlugin != nil {
+		if plugins, ok := raw["plugins"].([]interface{}); ok {
+			for i, plugin := range plugins {
+				if name, ok := plugin.(string); ok && !v.HasPlugin(name) {
+					file.add("error", fmt.Sprintf("/plugins/%d", i), "unknown plugin %q", name)
+				}
+			}
+		}
+	}
+}
+
+func (v *Validator) validateXPathConfig(file *configFile, raw map[string]interface{}) {
+	schemaErrors, err := ValidateSchema(XPathConfigSchema, raw)
+	if err != nil {
+		file.add("error", "", "%v", err)
+		return
+	}
+	for _, schemaErr := range schemaErrors {
+		file.add("error", schemaErr.Path, "%s: %s", displayPointer(schemaErr.Path), schemaErr.Message)
+	}
+	for _, field := range sortedKeys(raw) {
+		if expr, ok := raw[field].(string); ok && expr != "" && field != "$schema" {
+			checkXPath(file, "/"+escapePointer(field), expr)
+		}
+	}
+}
+
+// goquery と同じパーサーでCSSセレクターを検査する
+func checkSelector(file *configFile, pointer string, selector string) {
+	if _, err := cascadia.ParseGroup(selector); err != nil {
+		file.add("error", pointer, "invalid CSS selector %q: %v", selector, err)
+	}
+}
+
+func checkXPath(file *configFile, pointer string, expr string) {
+	if _, err := xpath.Compile(expr); err != nil {
+		file.add("error", pointer, "invalid XPath %q: %v", expr, err)
+	}
+}
+
+func checkRegex(file *configFile, pointer string, pattern string) {
+	if _, err := regexp.Compile(pattern); err != nil {
+		file.add("error", pointer, "invalid regex %q: %v", pattern, err)
+	}
+}
+
+// "/selectors/name" → "selectors.name"
+func displayPointer(pointer string) string {
+	if pointer == "" {
+		return "(root)"
+	}
+	parts := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, part := range parts {
+		parts[i] = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestValidateSelectorFields(t *testing.T) {
+	search := SearchPath{{Name: "test", FS: fstest.MapFS{
+		"sites/example.json": {Data: []byte(`{
+    "name": "example",
+    "domain": "example.org",
+    "selectors": {
+        "name": "h1",
+        "position": ".position",
+        "welfare": ".welfare"
+    }
+}
+`)},
+	}}}
+	validator := &Validator{Search: search, IsSelectorField: func(field string) bool { return field == "name" }}
+	issues, _ := validator.ValidateSearchPath()
+
+	var messages []string
+	for _, issue := range issues {
+		if issue.Severity == "error" {
+			messages = append(messages, issue.Message)
+		}
+	}
+	tests := []struct {
+		field string
+		want  []string // エラーに含まれる言葉（1件ずつ）
+	}{
+		{"name", nil},
+		// JobData のフィールドだが selectors では読まれない
+		{"position", []string{"use extractors.position"}},
+		// JobData にないフィールドはスキーマのエラーだけ
+		{"welfare", []string{`unknown field "welfare"`}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, message := range messages {
+			if strings.Contains(message, tt.field) {
+				got = append(got, message)
+			}
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("errors for %s = %q; want %d error(s)", tt.field, got, len(tt.want))
+			continue
+		}
+		for i, want := range tt.want {
+			if !strings.Contains(got[i], want) {
+				t.Errorf("error for %s = %q; want it to contain %q", tt.field, got[i], want)
+			}
+		}
+	}
+}
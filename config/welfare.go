@@ -0,0 +1,47 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// 福利厚生のタグ辞書（configs/welfare.json）
+type WelfareDictionary struct {
+	Version     string       `json:"version"`
+	Description string       `json:"description"`
+	Tags        []WelfareTag `json:"tags"` // タグはこの順に並べる
+}
+
+// タグ1つ分のルール
+type WelfareTag struct {
+	Tag      string   `json:"tag"`
+	Keywords []string `json:"keywords"` // どれかを含めばタグを付ける（直後に「なし」「不可」などが続くものは除く）
+	Exclude  []string `json:"exclude"`  // この言葉の一部として出てくるキーワードは数えない
+}
+
+// 空の辞書（タグを付けない）
+func EmptyWelfareDictionary() *WelfareDictionary {
+	return &WelfareDictionary{}
+}
+
+func LoadWelfareDictionary(path string) (*WelfareDictionary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseWelfareDictionary(data)
+}
+
+func parseWelfareDictionary(data []byte) (*WelfareDictionary, error) {
+	var dictionary WelfareDictionary
+	if err := json.Unmarshal(data, &dictionary); err != nil {
+		return nil, err
+	}
+	for i, tag := range dictionary.Tags {
+		if tag.Tag == "" || len(tag.Keywords) == 0 {
+			return nil, fmt.Errorf("tags[%d]: tag and keywords are required", i)
+		}
+	}
+	return &dictionary, nil
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// 旧形式のXPath設定（JobData のフィールドごとにXPathを1つ。scrape-xpath と render で使う）
+type XPathConfig struct {
+	Name           string `json:"name"`
+	Price          string `json:"price"`
+	Area           string `json:"area"`
+	Access         string `json:"access"`
+	Address        string `json:"address"`
+	City           string `json:"city"`
+	Prefecture     string `json:"prefecture"`
+	Contract       string `json:"contract"`
+	Dept           string `json:"dept"`
+	Detail         string `json:"detail"`
+	FacilityName   string `json:"facility_name"`
+	FacilityType   string `json:"facility_type"`
+	Holiday        string `json:"holiday"`
+	License        string `json:"license"`
+	Occupation     string `json:"occupation"`
+	Position       string `json:"position"`
+	RequiredSkill  string `json:"required_skill"`
+	StaffComment   string `json:"staff_comment"`
+	Station        string `json:"station"`
+	WelfareProgram string `json:"welfare_program"`
+	WorkingHours   string `json:"working_hours"`
+	WorkingStyle   string `json:"working_style"`
+	TitleOriginal  string `json:"title_original"`
+}
+
+func LoadXPathConfig(configFile string) (*XPathConfig, error) {
+	configData, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var config XPathConfig
+	if err := json.Unmarshal(configData, &config); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
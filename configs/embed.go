@@ -0,0 +1,12 @@
+// Package configs は標準のサイト設定・共有部品・ラベル辞書・福利厚生のタグ辞書をバイナリに埋め込む。
+//
+// config.DefaultSearchPath の最後の置き場所として使われるので、
+// 設定ディレクトリを用意しなくても標準のサイト設定で抽出できる。
+package configs
+
+import "embed"
+
+// sites/・fragments/・labels.json・welfare.json
+//
+//go:embed sites fragments labels.json welfare.json
+var FS embed.FS
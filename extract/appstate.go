@@ -0,0 +1,576 @@
+package extract
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/goodsun/jobscraper/config"
+)
+
+// 自動検出で探すアプリ状態の変数名
+var defaultAppStateVariables = []string{
+	"window.__NUXT__",
+	"__NUXT__",
+	"window.__INITIAL_STATE__",
+	"window.__PRELOADED_STATE__",
+	"window.__APOLLO_STATE__",
+	"window.__APP_STATE__",
+}
+
+// scriptタグに埋め込まれたアプリ状態（__NEXT_DATA__など）を探して解析する
+func findAppState(doc *goquery.Document, extractor config.ExtractorConfig, report *Report) interface{} {
+	if extractor.ScriptID != "" {
+		return parseAppStateScript(doc.Find("script#" + extractor.ScriptID).First().Text())
+	}
+
+	var scripts []string
+	doc.Find("script").Each(func(i int, s *goquery.Selection) {
+		scripts = append(scripts, s.Text())
+	})
+
+	if extractor.Pattern != "" {
+		re, err := regexp.Compile(extractor.Pattern)
+		if err != nil {
+			report.warnf("invalid app-state pattern %q: %v", extractor.Pattern, err)
+			return nil
+		}
+		for _, script := range scripts {
+			if matches := re.FindStringSubmatch(script); len(matches) > 1 {
+				if state := parseAppStateScript(matches[1]); state != nil {
+					return state
+				}
+			}
+		}
+		return nil
+	}
+
+	variables := defaultAppStateVariables
+	if extractor.Variable != "" {
+		variables = []string{extractor.Variable}
+	} else if state := parseAppStateScript(doc.Find("script#__NEXT_DATA__").First().Text()); state != nil {
+		return state
+	}
+
+	for _, variable := range variables {
+		assignRegex := regexp.MustCompile(`(?:^|[^\w$.])` + regexp.QuoteMeta(variable) + `\s*=\s*`)
+		for _, script := range scripts {
+			if loc := assignRegex.FindStringIndex(script); loc != nil {
+				if state := parseAppStateScript(script[loc[1]:]); state != nil {
+					return state
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// JSONとして読めなければJSのオブジェクトリテラルとして読む
+func parseAppStateScript(script string) interface{} {
+	script = strings.TrimSpace(script)
+	if script == "" {
+		return nil
+	}
+	var state interface{}
+	if err := json.Unmarshal([]byte(script), &state); err == nil {
+		return state
+	}
+	parser := &jsLiteralParser{src: script, env: map[string]interface{}{}}
+	state, err := parser.parseValue()
+	if err != nil {
+		return nil
+	}
+	return state
+}
+
+// JSのオブジェクトリテラル風の記述を読むための簡易パーサー
+// （クォートなしのキー、シングルクォート、末尾カンマ、!0/!1、void 0、
+//
+//	JSON.parse('...')、Nuxtの (function(a,b){return {...}}(1,2)) 形式に対応）
+type jsLiteralParser struct {
+	src string
+	pos int
+	env map[string]interface{}
+}
+
+func (p *jsLiteralParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("position %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *jsLiteralParser) skipSpace() {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+			p.pos++
+		} else if strings.HasPrefix(p.src[p.pos:], "//") {
+			end := strings.IndexByte(p.src[p.pos:], '\n')
+			if end < 0 {
+				p.pos = len(p.src)
+			} else {
+				p.pos += end
+			}
+		} else if strings.HasPrefix(p.src[p.pos:], "/*") {
+			end := strings.Index(p.src[p.pos+2:], "*/")
+			if end < 0 {
+				p.pos = len(p.src)
+			} else {
+				p.pos += end + 4
+			}
+		} else {
+			return
+		}
+	}
+}
+
+func (p *jsLiteralParser) peek() byte {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *jsLiteralParser) expect(c byte) error {
+	if p.peek() != c {
+		return p.errorf("expected %q", c)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *jsLiteralParser) parseValue() (interface{}, error) {
+	c := p.peek()
+	switch {
+	case c == 0:
+		return nil, p.errorf("unexpected end of input")
+	case c == '{':
+		return p.parseObject()
+	case c == '[':
+		return p.parseArray()
+	case c == '"' || c == '\'' || c == '`':
+		return p.parseString()
+	case c == '-' || c == '+' || c == '.' || (c >= '0' && c <= '9'):
+		return p.parseNumber()
+	case c == '!':
+		p.pos++
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return !jsTruthy(value), nil
+	case c == '(':
+		return p.parseParenthesized()
+	case isJSIdentStart(c):
+		return p.parseIdentifier()
+	}
+	return nil, p.errorf("unexpected character %q", c)
+}
+
+func (p *jsLiteralParser) parseObject() (interface{}, error) {
+	p.pos++
+	object := map[string]interface{}{}
+	for {
+		c := p.peek()
+		if c == '}' {
+			p.pos++
+			return object, nil
+		}
+
+		var key string
+		switch {
+		case c == '"' || c == '\'' || c == '`':
+			value, err := p.parseString()
+			if err != nil {
+				return nil, err
+			}
+			key = value.(string)
+		case isJSIdentStart(c) || (c >= '0' && c <= '9'):
+			start := p.pos
+			for p.pos < len(p.src) && isJSIdentPart(p.src[p.pos]) {
+				p.pos++
+			}
+			key = p.src[start:p.pos]
+		default:
+			return nil, p.errorf("unexpected character %q in object key", c)
+		}
+
+		// {a, b} のような省略記法は環境から値を引く
+		if c := p.peek(); c == ',' || c == '}' {
+			object[key] = p.env[key]
+		} else {
+			if err := p.expect(':'); err != nil {
+				return nil, err
+			}
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			object[key] = value
+		}
+
+		if p.peek() == ',' {
+			p.pos++
+		} else if p.peek() != '}' {
+			return nil, p.errorf("expected ',' or '}'")
+		}
+	}
+}
+
+func (p *jsLiteralParser) parseArray() (interface{}, error) {
+	p.pos++
+	array := []interface{}{}
+	for {
+		if p.peek() == ']' {
+			p.pos++
+			return array, nil
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		array = append(array, value)
+		if p.peek() == ',' {
+			p.pos++
+		} else if p.peek() != ']' {
+			return nil, p.errorf("expected ',' or ']'")
+		}
+	}
+}
+
+func (p *jsLiteralParser) parseString() (interface{}, error) {
+	quote := p.src[p.pos]
+	p.pos++
+	var b strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == quote {
+			p.pos++
+			return b.String(), nil
+		}
+		if c != '\\' {
+			b.WriteByte(c)
+			p.pos++
+			continue
+		}
+		p.pos++
+		if p.pos >= len(p.src) {
+			break
+		}
+		esc := p.src[p.pos]
+		p.pos++
+		switch esc {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'v':
+			b.WriteByte('\v')
+		case '0':
+			b.WriteByte(0)
+		case '\n':
+			// 行継続
+		case 'x':
+			if p.pos+2 <= len(p.src) {
+				if n, err := strconv.ParseUint(p.src[p.pos:p.pos+2], 16, 8); err == nil {
+					b.WriteRune(rune(n))
+					p.pos += 2
+				}
+			}
+		case 'u':
+			b.WriteRune(p.parseUnicodeEscape())
+		default:
+			b.WriteByte(esc)
+		}
+	}
+	return nil, p.errorf("unterminated string")
+}
+
+func (p *jsLiteralParser) parseUnicodeEscape() rune {
+	if p.pos < len(p.src) && p.src[p.pos] == '{' {
+		end := strings.IndexByte(p.src[p.pos:], '}')
+		if end > 0 {
+			if n, err := strconv.ParseUint(p.src[p.pos+1:p.pos+end], 16, 32); err == nil {
+				p.pos += end + 1
+				return rune(n)
+			}
+		}
+		return utf8.RuneError
+	}
+	if p.pos+4 > len(p.src) {
+		return utf8.RuneError
+	}
+	n, err := strconv.ParseUint(p.src[p.pos:p.pos+4], 16, 16)
+	if err != nil {
+		return utf8.RuneError
+	}
+	p.pos += 4
+	r := rune(n)
+	// サロゲートペア
+	if utf16.IsSurrogate(r) && strings.HasPrefix(p.src[p.pos:], "\\u") && p.pos+6 <= len(p.src) {
+		if n2, err := strconv.ParseUint(p.src[p.pos+2:p.pos+6], 16, 16); err == nil {
+			p.pos += 6
+			return utf16.DecodeRune(r, rune(n2))
+		}
+	}
+	return r
+}
+
+func (p *jsLiteralParser) parseNumber() (interface{}, error) {
+	start := p.pos
+	for p.pos < len(p.src) && strings.IndexByte("+-.0123456789eExXabcdefABCDEF_", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+	text := strings.ReplaceAll(p.src[start:p.pos], "_", "")
+	if n, err := strconv.ParseFloat(text, 64); err == nil {
+		return n, nil
+	}
+	if n, err := strconv.ParseInt(text, 0, 64); err == nil {
+		return float64(n), nil
+	}
+	return nil, p.errorf("invalid number %q", text)
+}
+
+func (p *jsLiteralParser) readIdentifier() string {
+	start := p.pos
+	for p.pos < len(p.src) && (isJSIdentPart(p.src[p.pos]) || p.src[p.pos] == '.') {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *jsLiteralParser) parseIdentifier() (interface{}, error) {
+	ident := p.readIdentifier()
+	switch ident {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null", "undefined", "NaN", "Infinity":
+		return nil, nil
+	case "void":
+		_, err := p.parseValue()
+		return nil, err
+	case "new":
+		p.skipSpace()
+		p.readIdentifier()
+		if p.peek() == '(' {
+			return nil, p.skipBalanced()
+		}
+		return nil, nil
+	case "function":
+		return p.parseFunction()
+	case "JSON.parse":
+		if err := p.expect('('); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(')'); err != nil {
+			return nil, err
+		}
+		text, ok := value.(string)
+		if !ok {
+			return nil, p.errorf("JSON.parse argument is not a string")
+		}
+		return parseAppStateScript(text), nil
+	}
+
+	// 関数呼び出しは値として扱えないのでnull
+	if p.peek() == '(' {
+		return nil, p.skipBalanced()
+	}
+	return p.env[ident], nil
+}
+
+// (function(a,b){return {...}}(1,2)) や (function(a,b){...})(1,2) の形式
+func (p *jsLiteralParser) parseParenthesized() (interface{}, error) {
+	p.pos++
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+	if fn, ok := value.(*jsFunction); ok && p.peek() == '(' {
+		return p.callFunction(fn)
+	}
+	return value, nil
+}
+
+type jsFunction struct {
+	params []string
+	body   string
+}
+
+func (p *jsLiteralParser) parseFunction() (interface{}, error) {
+	p.skipSpace()
+	p.readIdentifier() // 関数名（あれば）
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	start := p.pos
+	end := strings.IndexByte(p.src[start:], ')')
+	if end < 0 {
+		return nil, p.errorf("unterminated parameter list")
+	}
+	var params []string
+	for _, param := range strings.Split(p.src[start:start+end], ",") {
+		if param = strings.TrimSpace(param); param != "" {
+			params = append(params, param)
+		}
+	}
+	p.pos = start + end + 1
+
+	if p.peek() != '{' {
+		return nil, p.errorf("expected function body")
+	}
+	bodyStart := p.pos
+	if err := p.skipBalanced(); err != nil {
+		return nil, err
+	}
+	fn := &jsFunction{params: params, body: p.src[bodyStart+1 : p.pos-1]}
+
+	// function(){...}(args) の即時実行
+	if p.peek() == '(' {
+		return p.callFunction(fn)
+	}
+	return fn, nil
+}
+
+func (p *jsLiteralParser) callFunction(fn *jsFunction) (interface{}, error) {
+	args, err := p.parseArguments()
+	if err != nil {
+		return nil, err
+	}
+	env := map[string]interface{}{}
+	for k, v := range p.env {
+		env[k] = v
+	}
+	for i, param := range fn.params {
+		if i < len(args) {
+			env[param] = args[i]
+		} else {
+			env[param] = nil
+		}
+	}
+
+	returnAt := topLevelIndex(fn.body, "return")
+	if returnAt < 0 {
+		return nil, nil
+	}
+	body := &jsLiteralParser{src: fn.body, pos: returnAt + len("return"), env: env}
+	return body.parseValue()
+}
+
+// 関数呼び出しの引数リストを読む
+func (p *jsLiteralParser) parseArguments() ([]interface{}, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	var values []interface{}
+	for {
+		if p.peek() == ')' {
+			p.pos++
+			return values, nil
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		if p.peek() == ',' {
+			p.pos++
+		} else if p.peek() != ')' {
+			return nil, p.errorf("expected ',' or ')'")
+		}
+	}
+}
+
+// 文字列を考慮して対応する括弧の直後まで読み飛ばす
+func (p *jsLiteralParser) skipBalanced() error {
+	depth := 0
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch c {
+		case '"', '\'', '`':
+			if _, err := p.parseString(); err != nil {
+				return err
+			}
+			continue
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 {
+				p.pos++
+				return nil
+			}
+		}
+		p.pos++
+	}
+	return p.errorf("unbalanced brackets")
+}
+
+// 括弧・文字列の外側にある最初のキーワード位置
+func topLevelIndex(src string, keyword string) int {
+	depth := 0
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch c {
+		case '"', '\'', '`':
+			p := &jsLiteralParser{src: src, pos: i}
+			if _, err := p.parseString(); err != nil {
+				return -1
+			}
+			i = p.pos - 1
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		default:
+			if depth == 0 && strings.HasPrefix(src[i:], keyword) &&
+				(i == 0 || !isJSIdentPart(src[i-1])) &&
+				(i+len(keyword) >= len(src) || !isJSIdentPart(src[i+len(keyword)])) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isJSIdentStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isJSIdentPart(c byte) bool {
+	return isJSIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+func jsTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	}
+	return true
+}
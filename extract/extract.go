@@ -0,0 +1,228 @@
+// Package extract は求人ページのHTMLから求人データを抽出する。
+package extract
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/goodsun/jobscraper/config"
+	"github.com/goodsun/jobscraper/job"
+)
+
+// 抽出中に気づいたこと（report を渡した場合のみ記録する）
+type Report struct {
+	Warnings       []string     `json:"warnings,omitempty"`
+	UnmappedLabels []LabelValue `json:"unmapped_labels,omitempty"` // ラベル辞書に未登録のラベル
+	Trace          *Trace       `json:"trace,omitempty"`           // NewTrace() を入れておくとフィールドごとの経過を記録する
+}
+
+func (r *Report) warnf(format string, args ...interface{}) {
+	if r != nil {
+		r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+	}
+}
+
+func (r *Report) trace() *Trace {
+	if r == nil {
+		return nil
+	}
+	return r.Trace
+}
+
+func extractWithSelector(doc *goquery.Document, selector string) string {
+	return strings.TrimSpace(doc.Find(selector).First().Text())
+}
+
+// site.Selectors で指定できるフィールド（他のフィールドは selector 型の extractor を使う）
+// name・price 以外はこの順に、JSON-LDで取れなかった場合のみ使う
+var selectorFields = []string{
+	"name", "price", "facility_name", "area", "access", "occupation", "contract", "staff_comment", "dept",
+	"detail", "facility_type", "holiday", "license", "required_skill", "station", "welfare_program",
+	"working_hours", "working_style",
+}
+
+func IsSelectorField(field string) bool {
+	for _, name := range selectorFields {
+		if name == field {
+			return true
+		}
+	}
+	return false
+}
+
+// サイト設定とラベル辞書に従ってHTMLから求人データを抽出する（labels が nil なら辞書なし、report は nil 可）
+// 住所などの正規化は行わないので、必要なら normalize パッケージを使う
+func Extract(htmlContent string, site *config.SiteConfig, labels *config.LabelDictionary, report *Report) (*job.JobData, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil, err
+	}
+	if labels == nil {
+		labels = config.EmptyLabelDictionary()
+	}
+
+	data := &job.JobData{}
+	w := newFieldWriter(data, report.trace(), "json-ld")
+
+	// JSON-LD extraction (共通)
+	extractJSONLD(doc, w)
+
+	// セレクターベースの抽出（ハイブリッド方式：JSON-LDとセレクターを組み合わせ）
+	if site.Selectors != nil {
+		selectors := w.as("selector")
+		for _, field := range selectorFields {
+			selector, ok := site.Selectors[field]
+			if !ok {
+				continue
+			}
+			value := extractWithSelector(doc, selector)
+			switch {
+			case value == "":
+				selectors.missed(field, selector, "no match")
+			case field == "name":
+				// 重要な基本情報は常にセレクターを優先（既存サイト互換性のため）
+				selectors.set("name", selector, value)
+				selectors.set("title_original", selector, value)
+			case field == "price":
+				selectors.set("price", selector, value)
+			default:
+				// その他の情報はJSON-LDを優先し、取得できない場合のみセレクターを使用
+				selectors.fill(field, selector, value)
+			}
+		}
+	}
+
+	// extractors（埋め込みJSONなど）による抽出
+	if len(site.Extractors) > 0 {
+		applyExtractors(doc, htmlContent, site, w.as("extractor"), report)
+	}
+
+	// 表形式（th/td、dt/dd など）のラベルから空の項目を補完
+	if site.HarvestEnabled() {
+		applyHarvestedLabels(doc, site, labels, w.as("labels"))
+	}
+
+	// 取れなかった項目を汎用メタデータで補完
+	extractFallbackMetadata(doc, w)
+
+	// サイト固有のプラグインで仕上げる
+	found, missing := sitePlugins(site)
+	for _, name := range missing {
+		report.warnf("unknown plugin %q", name)
+	}
+	for _, plugin := range found {
+		if plugin.PostProcess == nil {
+			continue
+		}
+		before := *data
+		if err := plugin.PostProcess(doc, site, data); err != nil {
+			return nil, fmt.Errorf("plugin %s: %v", plugin.Name, err)
+		}
+		w.as("plugin").recordChanges(before, plugin.Name)
+	}
+
+	if report != nil {
+		report.UnmappedLabels = UnmappedLabels(CollectLabels(doc, site), site, labels)
+		report.Trace.Finish(data)
+	}
+
+	return data, nil
+}
+
+// extractorsの設定に従って抽出（値が取れたフィールドはセレクター・JSON-LDより優先）
+func applyExtractors(doc *goquery.Document, htmlContent string, site *config.SiteConfig, w fieldWriter, report *Report) {
+	states := map[string]interface{}{}
+	var postings []map[string]interface{}
+
+	// 出力を安定させるためフィールド名順に処理
+	var names []string
+	for name := range site.Extractors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, ok := w.fields[name]; !ok {
+			continue
+		}
+		extractor := site.Extractors[name]
+
+		var value string
+		switch extractor.Type {
+		case "selector":
+			value = extractWithExtractorSelector(doc, extractor)
+		case "xpath":
+			value = extractWithExtractorXPath(doc, extractor, report)
+		case "regex":
+			pattern := extractor.Value
+			if strings.HasPrefix(pattern, "@") {
+				// patterns に名前を付けて書いた正規表現を参照
+				named, ok := site.Patterns[pattern[1:]]
+				if !ok {
+					report.warnf("undefined pattern %q for %s", pattern[1:], name)
+					continue
+				}
+				pattern = named
+			}
+			value = extractWithRegex(htmlContent, pattern, report)
+		case "json-ld":
+			if postings == nil {
+				postings = findJobPostings(doc)
+			}
+			for _, posting := range postings {
+				if value = decodeHTMLEntities(appStateValueString(evalJSONPath(posting, extractor.Value))); value != "" {
+					break
+				}
+			}
+		case "app-state":
+			key := extractor.ScriptID + "\x00" + extractor.Variable + "\x00" + extractor.Pattern
+			state, ok := states[key]
+			if !ok {
+				state = findAppState(doc, extractor, report)
+				states[key] = state
+			}
+			if state != nil {
+				value = appStateValueString(evalJSONPath(state, extractor.Value))
+			}
+		default:
+			report.warnf("unknown extractor type %q for %s", extractor.Type, name)
+			continue
+		}
+
+		source := extractor.Type + " " + extractor.Value
+		if !w.set(name, source, value) {
+			w.missed(name, source, "no value")
+		}
+	}
+}
+
+func extractWithExtractorSelector(doc *goquery.Document, extractor config.ExtractorConfig) string {
+	selection := doc.Find(extractor.Value).Eq(extractor.Index)
+	if selection.Length() == 0 {
+		return ""
+	}
+	if extractor.Attr != "" && extractor.Attr != "text" {
+		value, _ := selection.Attr(extractor.Attr)
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(selection.Text())
+}
+
+func extractWithRegex(content string, pattern string, report *Report) string {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		report.warnf("invalid regex %q: %v", pattern, err)
+		return ""
+	}
+	matches := re.FindStringSubmatch(content)
+	if len(matches) > 1 {
+		return strings.TrimSpace(matches[1])
+	} else if len(matches) == 1 {
+		return strings.TrimSpace(matches[0])
+	}
+	return ""
+}
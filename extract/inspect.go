@@ -0,0 +1,24 @@
+package extract
+
+import (
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/goodsun/jobscraper/config"
+)
+
+// 抽出の各手段を1つずつ試す（shell などでの調査用）
+
+// ページ内のJSON-LD JobPosting
+func JobPostings(doc *goquery.Document) []map[string]interface{} {
+	return findJobPostings(doc)
+}
+
+// extractors の json-ld・app-state と同じJSONパスで値をたどる
+func EvalJSONPath(root interface{}, path string) []interface{} {
+	return evalJSONPath(root, path)
+}
+
+// app-state 型の extractor と同じ方法で埋め込みJSONを探す（見つからなければ nil）
+func AppState(doc *goquery.Document, extractor config.ExtractorConfig, report *Report) interface{} {
+	return findAppState(doc, extractor, report)
+}
@@ -0,0 +1,342 @@
+package extract
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/goodsun/jobscraper/normalize"
+)
+
+// JSON-LD スキーマの抽出
+func extractJSONLD(doc *goquery.Document, w fieldWriter) {
+	for _, posting := range findJobPostings(doc) {
+		extractFromJobPosting(posting, w)
+	}
+}
+
+// ページ内の全JSON-LDからJobPostingを集める（@graph・配列・入れ子に対応）
+func findJobPostings(doc *goquery.Document) []map[string]interface{} {
+	var postings []map[string]interface{}
+	doc.Find(`script[type="application/ld+json"]`).Each(func(i int, s *goquery.Selection) {
+		if value := parseJSONLDScript(s.Text()); value != nil {
+			postings = append(postings, collectJobPostings(value)...)
+		}
+	})
+	return postings
+}
+
+func parseJSONLDScript(script string) interface{} {
+	script = strings.TrimSpace(script)
+	script = strings.TrimPrefix(script, "<!--")
+	script = strings.TrimSuffix(script, "-->")
+	script = strings.TrimPrefix(strings.TrimSpace(script), "//<![CDATA[")
+	script = strings.TrimSuffix(strings.TrimSpace(script), "//]]>")
+
+	// 末尾カンマや文字列中の改行などで壊れたJSONはJSリテラルとして読む
+	return parseAppStateScript(script)
+}
+
+func collectJobPostings(value interface{}) []map[string]interface{} {
+	var postings []map[string]interface{}
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			postings = append(postings, collectJobPostings(item)...)
+		}
+	case map[string]interface{}:
+		if isJSONLDType(v, "JobPosting") {
+			return append(postings, v)
+		}
+		// @graph や mainEntity などの入れ子を探す
+		for _, child := range sortedJSONValues(v) {
+			postings = append(postings, collectJobPostings(child)...)
+		}
+	}
+	return postings
+}
+
+// @type が文字列・配列・"http://schema.org/JobPosting" のいずれでも判定する
+func isJSONLDType(item map[string]interface{}, typeName string) bool {
+	var types []interface{}
+	switch t := item["@type"].(type) {
+	case string:
+		types = []interface{}{t}
+	case []interface{}:
+		types = t
+	}
+	for _, t := range types {
+		if s, ok := t.(string); ok {
+			if s == typeName || strings.HasSuffix(s, "/"+typeName) || strings.HasSuffix(s, ":"+typeName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// JSON-LDの値を文字列にする（配列は「、」区切り、オブジェクトは name / value を使う）
+func jsonLDText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		var parts []string
+		for _, item := range v {
+			if s := jsonLDText(item); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, "、")
+	case map[string]interface{}:
+		for _, key := range []string{"name", "value", "@value", "description"} {
+			if s := jsonLDText(v[key]); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// 数値・数値文字列（"250,000" なども）をfloat64にする
+func jsonLDNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		n, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(v), ",", ""), 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// 単一オブジェクトでも配列でも最初のオブジェクトを返す
+func firstJSONLDObject(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case []interface{}:
+		for _, item := range v {
+			if object, ok := firstJSONLDObject(item); ok {
+				return object, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// 二重エスケープされたHTMLエンティティも戻す
+func decodeHTMLEntities(s string) string {
+	for i := 0; i < 3 && strings.Contains(s, "&"); i++ {
+		decoded := html.UnescapeString(s)
+		if decoded == s {
+			break
+		}
+		s = decoded
+	}
+	return s
+}
+
+var salaryUnitLabels = map[string]string{
+	"HOUR":  "時給",
+	"DAY":   "日給",
+	"WEEK":  "週給",
+	"MONTH": "月収",
+	"YEAR":  "年収",
+}
+
+func formatBaseSalary(baseSalary interface{}) string {
+	salary, ok := firstJSONLDObject(baseSalary)
+	if !ok {
+		if n, ok := jsonLDNumber(baseSalary); ok {
+			return fmt.Sprintf("月収 %s円", strconv.FormatFloat(n, 'f', -1, 64))
+		}
+		return ""
+	}
+
+	unit := jsonLDText(salary["unitText"])
+	var minVal, maxVal float64
+	var hasMin, hasMax bool
+
+	if value, ok := firstJSONLDObject(salary["value"]); ok {
+		minVal, hasMin = jsonLDNumber(value["minValue"])
+		maxVal, hasMax = jsonLDNumber(value["maxValue"])
+		if !hasMin && !hasMax {
+			minVal, hasMin = jsonLDNumber(value["value"])
+		}
+		if u := jsonLDText(value["unitText"]); u != "" {
+			unit = u
+		}
+	} else {
+		minVal, hasMin = jsonLDNumber(salary["value"])
+	}
+
+	label, ok := salaryUnitLabels[strings.ToUpper(unit)]
+	if !ok {
+		label = "月収"
+	}
+	format := func(n float64) string { return strconv.FormatFloat(n, 'f', -1, 64) }
+
+	switch {
+	case hasMin && hasMax && minVal != maxVal:
+		return fmt.Sprintf("%s %s〜%s円", label, format(minVal), format(maxVal))
+	case hasMin:
+		if hasMax {
+			return fmt.Sprintf("%s %s円", label, format(minVal))
+		}
+		return fmt.Sprintf("%s %s円〜", label, format(minVal))
+	case hasMax:
+		return fmt.Sprintf("%s 〜%s円", label, format(maxVal))
+	}
+	return ""
+}
+
+func extractFromJobPosting(item map[string]interface{}, w fieldWriter) {
+	data := w.data
+	desc := decodeHTMLEntities(jsonLDText(item["description"]))
+
+	// タイトル
+	w.fillName("title", jsonLDText(item["title"]))
+	if desc != "" && data.Name == "" {
+		lines := strings.Split(desc, "<br>")
+		if len(lines) > 0 {
+			w.fillName("description (first line)", strings.TrimSpace(lines[0]))
+		}
+	}
+
+	// 給与
+	if data.Price == "" {
+		w.fill("price", "baseSalary", formatBaseSalary(item["baseSalary"]))
+	}
+
+	// 勤務地（複数ある場合は最初の勤務地）
+	if jobLocation, ok := firstJSONLDObject(item["jobLocation"]); ok {
+		if address, ok := firstJSONLDObject(jobLocation["address"]); ok {
+			w.fill("prefecture", "jobLocation.address.addressRegion", jsonLDText(address["addressRegion"]))
+			w.fill("city", "jobLocation.address.addressLocality", jsonLDText(address["addressLocality"]))
+			if street := jsonLDText(address["streetAddress"]); street != "" {
+				// streetAddress に都道府県から入っているサイトもある
+				if data.Prefecture != "" && strings.HasPrefix(street, data.Prefecture) {
+					w.fill("address", "jobLocation.address.streetAddress", street)
+				} else {
+					w.fill("address", "addressRegion + addressLocality + streetAddress", fmt.Sprintf("%s%s%s", data.Prefecture, data.City, street))
+				}
+			}
+			w.fill("area", "addressRegion + addressLocality", data.Prefecture+data.City)
+		} else {
+			w.fill("address", "jobLocation.address", jsonLDText(jobLocation["address"]))
+		}
+	}
+
+	// 施設名
+	w.fill("facility_name", "hiringOrganization", jsonLDText(item["hiringOrganization"]))
+
+	// 職種カテゴリー
+	w.fill("occupation", "occupationalCategory", jsonLDText(item["occupationalCategory"]))
+
+	// 雇用形態（配列の場合は「、」区切り。schema.org の値もそのまま残し、正規の値には normalize で直す）
+	var contracts []string
+	for _, empType := range strings.Split(jsonLDText(item["employmentType"]), "、") {
+		empType = strings.TrimSpace(empType)
+		if empType != "" && !containsString(contracts, empType) {
+			contracts = append(contracts, empType)
+		}
+	}
+	w.fill("contract", "employmentType", strings.Join(contracts, "、"))
+
+	// 勤務時間
+	w.fill("working_hours", "workHours", decodeHTMLEntities(jsonLDText(item["workHours"])))
+
+	// 必要資格
+	w.fill("license", "qualifications", decodeHTMLEntities(jsonLDText(item["qualifications"])))
+
+	// 必要な経験
+	w.fill("required_skill", "experienceRequirements", decodeHTMLEntities(jsonLDText(item["experienceRequirements"])))
+
+	// 仕事内容
+	w.fill("detail", "responsibilities", decodeHTMLEntities(jsonLDText(item["responsibilities"])))
+
+	// 福利厚生
+	w.fill("welfare_program", "jobBenefits", decodeHTMLEntities(jsonLDText(item["jobBenefits"])))
+
+	// 掲載日・掲載期限・求人ID・応募方法
+	w.fill("date_posted", "datePosted", jsonLDText(item["datePosted"]))
+	w.fill("valid_through", "validThrough", jsonLDText(item["validThrough"]))
+	identifier := jsonLDText(item["identifier"])
+	if propertyValue, ok := firstJSONLDObject(item["identifier"]); ok && jsonLDText(propertyValue["value"]) != "" {
+		// PropertyValue は name が発行元、value がIDなので value を使う
+		identifier = jsonLDText(propertyValue["value"])
+	}
+	w.fill("identifier", "identifier", identifier)
+	if directApply, ok := item["directApply"].(bool); ok {
+		w.fill("direct_apply", "directApply", strconv.FormatBool(directApply))
+	}
+
+	// descriptionから詳細情報を抽出
+	if desc != "" {
+		extractFromDescription(desc, w.as(w.strategy+" description"))
+	}
+}
+
+// descriptionから詳細情報を抽出する関数
+func extractFromDescription(desc string, w fieldWriter) {
+	// 雇用形態の抽出（書かれている言葉をそのまま使い、正規の値には normalize で直す）
+	if contracts := normalize.EmploymentWords(desc); len(contracts) > 0 {
+		w.fill("contract", "「"+strings.Join(contracts, "」「")+"」", strings.Join(contracts, "、"))
+	}
+
+	// 配属先の抽出
+	if strings.Contains(desc, "配属先：病棟") || strings.Contains(desc, "病棟") {
+		w.fill("position", "「病棟」", "病棟")
+	} else if strings.Contains(desc, "配属先：外来") || strings.Contains(desc, "外来") {
+		w.fill("position", "「外来」", "外来")
+	} else if strings.Contains(desc, "配属先：手術室") || strings.Contains(desc, "手術室") {
+		w.fill("position", "「手術室」", "手術室")
+	}
+
+	// 診療科目の抽出
+	if matches := deptRegex.FindStringSubmatch(desc); len(matches) > 1 {
+		w.fill("dept", "「診療科目：」", strings.TrimSpace(matches[1]))
+	}
+
+	// 施設形態の抽出
+	if matches := facilityRegex.FindStringSubmatch(desc); len(matches) > 1 {
+		w.fill("facility_type", "「施設形態：」", strings.TrimSpace(matches[1]))
+	}
+
+	// 勤務形態の抽出（2交替、3交替、日勤のみ、夜勤専従、オンコール等）
+	var styles []string
+	for _, word := range workingStyleWordRegex.FindAllString(desc, -1) {
+		if !containsString(styles, word) {
+			styles = append(styles, word)
+		}
+	}
+	if len(styles) > 0 {
+		w.fill("working_style", "「"+strings.Join(styles, "」「")+"」", strings.Join(styles, "、"))
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// descriptionの勤務形態の言葉
+var workingStyleWordRegex = regexp.MustCompile(`[2二3三]交[替代]制?|日勤のみ|日勤常勤|夜勤専従|夜勤[有あ]り|夜勤[無な]し|オンコール[有あ]り|オンコール[無な]し|シフト制`)
+
+// 診療科目・施設形態の「ラベル：値」（値は改行・タグ・「。」・次の「ラベル：」の手前まで）
+var deptRegex = regexp.MustCompile(`診療科目[：:]\s*([^<\n。【■]+?)\s*(?:[<\n。【■]|\s\S{2,10}[：:]|$)`)
+var facilityRegex = regexp.MustCompile(`施設形態[：:]\s*([^<\n。【■]+?)\s*(?:[<\n。【■]|\s\S{2,10}[：:]|$)`)
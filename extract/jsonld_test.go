@@ -0,0 +1,36 @@
+package extract
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/goodsun/jobscraper/job"
+)
+
+func TestExtractJSONLDContract(t *testing.T) {
+	tests := []struct {
+		employmentType string
+		description    string
+		want           string
+	}{
+		// schema.org の値もそのまま残す
+		{`["FULL_TIME", "CONTRACTOR", "FULL_TIME"]`, `""`, "FULL_TIME、CONTRACTOR"},
+		{`"Part_Time"`, `""`, "Part_Time"},
+		// employmentType がなければ description の言葉を拾う（「正社員登用」は除く）
+		{`""`, `"常勤・非常勤（正社員登用あり）、常勤"`, "常勤、非常勤"},
+	}
+	for _, tt := range tests {
+		page := `<html><head><script type="application/ld+json">{"@type": "JobPosting", "employmentType": ` +
+			tt.employmentType + `, "description": ` + tt.description + `}</script></head></html>`
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var data job.JobData
+		extractJSONLD(doc, newFieldWriter(&data, nil, "jsonld"))
+		if data.Contract != tt.want {
+			t.Errorf("employmentType %s, description %s: Contract = %q; want %q", tt.employmentType, tt.description, data.Contract, tt.want)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package extract
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONパス（props.pageProps.job.title, jobs[0].name, items[*].label, ..title）を評価する
+func evalJSONPath(root interface{}, path string) []interface{} {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "$")
+	current := []interface{}{root}
+
+	for path != "" {
+		var key string
+		recursive := false
+		switch {
+		case strings.HasPrefix(path, ".."):
+			recursive = true
+			path = path[2:]
+			key, path = splitJSONPathKey(path)
+		case path[0] == '.':
+			key, path = splitJSONPathKey(path[1:])
+		case path[0] == '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return nil
+			}
+			key = "[" + path[1:end] + "]"
+			path = path[end+1:]
+		default:
+			key, path = splitJSONPathKey(path)
+		}
+
+		var next []interface{}
+		for _, node := range current {
+			if recursive {
+				next = append(next, findJSONKeyRecursive(node, key)...)
+			} else {
+				next = append(next, stepJSONPath(node, key)...)
+			}
+		}
+		current = next
+		if len(current) == 0 {
+			return nil
+		}
+	}
+	return current
+}
+
+func splitJSONPathKey(path string) (string, string) {
+	end := strings.IndexAny(path, ".[")
+	if end < 0 {
+		return path, ""
+	}
+	return path[:end], path[end:]
+}
+
+func stepJSONPath(node interface{}, key string) []interface{} {
+	if strings.HasPrefix(key, "[") {
+		inner := strings.Trim(key[1:len(key)-1], " ")
+		if inner == "*" {
+			switch v := node.(type) {
+			case []interface{}:
+				return v
+			case map[string]interface{}:
+				return sortedJSONValues(v)
+			}
+			return nil
+		}
+		if strings.HasPrefix(inner, "'") || strings.HasPrefix(inner, "\"") {
+			key = strings.Trim(inner, "'\"")
+		} else if index, err := strconv.Atoi(inner); err == nil {
+			array, ok := node.([]interface{})
+			if !ok {
+				return nil
+			}
+			if index < 0 {
+				index += len(array)
+			}
+			if index < 0 || index >= len(array) {
+				return nil
+			}
+			return []interface{}{array[index]}
+		} else {
+			key = inner
+		}
+	}
+
+	if key == "*" {
+		return stepJSONPath(node, "[*]")
+	}
+	if object, ok := node.(map[string]interface{}); ok {
+		if value, ok := object[key]; ok {
+			return []interface{}{value}
+		}
+	}
+	return nil
+}
+
+func findJSONKeyRecursive(node interface{}, key string) []interface{} {
+	var found []interface{}
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if value, ok := v[key]; ok {
+			found = append(found, value)
+		}
+		for _, child := range sortedJSONValues(v) {
+			found = append(found, findJSONKeyRecursive(child, key)...)
+		}
+	case []interface{}:
+		for _, child := range v {
+			found = append(found, findJSONKeyRecursive(child, key)...)
+		}
+	}
+	return found
+}
+
+func sortedJSONValues(object map[string]interface{}) []interface{} {
+	keys := make([]string, 0, len(object))
+	for k := range object {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	values := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		values = append(values, object[k])
+	}
+	return values
+}
+
+// JSONの値を出力用の文字列にする（配列は「、」区切り）
+func appStateValueString(values []interface{}) string {
+	var parts []string
+	for _, value := range values {
+		if s := jsonValueString(value); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, "、")
+}
+
+func jsonValueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		return appStateValueString(v)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,48 @@
+package extract
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/goodsun/jobscraper/config"
+	"github.com/goodsun/jobscraper/job"
+)
+
+// kirara-support.jp（表は configs/sites/kirara-support.json で取り、設定で書けない規則だけをここに残す）
+func init() {
+	RegisterPlugin(Plugin{
+		Name:        "kirara-support",
+		Domains:     []string{"kirara-support.jp"},
+		PostProcess: kiraraSupport,
+	})
+}
+
+func kiraraSupport(doc *goquery.Document, site *config.SiteConfig, data *job.JobData) error {
+	// 施設形態の行がない求人は、ページのタイトルから推測する
+	if data.FacilityType == "" {
+		titleText := doc.Find("title").Text()
+		if strings.Contains(titleText, "クリニック") {
+			data.FacilityType = "クリニック"
+		} else if strings.Contains(titleText, "病院") {
+			data.FacilityType = "病院"
+		}
+	}
+
+	// JSON-LDの title は求人タイトルではなく職種（「看護師」）
+	if data.Occupation == "" {
+		for _, posting := range findJobPostings(doc) {
+			if title := jsonLDText(posting["title"]); title != "" {
+				data.Occupation = title
+				break
+			}
+		}
+	}
+
+	// 求人タイトルから役職を取る
+	if data.Position == "" && strings.Contains(data.Name, "管理職") {
+		data.Position = "管理職候補"
+	}
+
+	return nil
+}
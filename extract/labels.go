@@ -0,0 +1,166 @@
+package extract
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/goodsun/jobscraper/config"
+	"github.com/goodsun/jobscraper/job"
+)
+
+// ページから集めたラベルと値の組
+type LabelValue struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// th/td、dt/dd、設定された見出し/値の組からラベル→値を集める
+func harvestLabels(doc *goquery.Document, site *config.SiteConfig) []LabelValue {
+	var pairs []LabelValue
+	seen := map[string]bool{}
+	add := func(label string, value string) {
+		label = config.NormalizeLabel(label)
+		value = strings.TrimSpace(value)
+		if label == "" || value == "" || seen[label] {
+			return
+		}
+		seen[label] = true
+		pairs = append(pairs, LabelValue{Label: label, Value: value})
+	}
+
+	doc.Find("th").Each(func(i int, s *goquery.Selection) {
+		add(s.Text(), s.NextAllFiltered("td").First().Text())
+	})
+	doc.Find("dt").Each(func(i int, s *goquery.Selection) {
+		// 1つのdtに複数のddが続く場合はまとめる
+		var values []string
+		s.NextUntil("dt").Filter("dd").Each(func(j int, dd *goquery.Selection) {
+			if text := strings.TrimSpace(dd.Text()); text != "" {
+				values = append(values, text)
+			}
+		})
+		add(s.Text(), strings.Join(values, "\n"))
+	})
+	for _, pair := range site.LabelPairs {
+		doc.Find(pair.Head).Each(func(i int, s *goquery.Selection) {
+			add(s.Text(), s.NextAllFiltered(pair.Value).First().Text())
+		})
+	}
+
+	return pairs
+}
+
+// 集めたラベルを各フィールドに割り当てる
+// 優先順位：完全一致 → 部分一致、その中では候補ラベルの並び順 → ページ上の出現順
+func MapLabelsToFields(pairs []LabelValue, site *config.SiteConfig, labels *config.LabelDictionary) map[string]string {
+	values := map[string]string{}
+	for field, pair := range mapLabels(pairs, site, labels) {
+		values[field] = pair.Value
+	}
+	return values
+}
+
+// フィールドごとに割り当てたラベルと値
+func mapLabels(pairs []LabelValue, site *config.SiteConfig, labels *config.LabelDictionary) map[string]LabelValue {
+	mapped := map[string]LabelValue{}
+	ignored := site.IgnoredLabels()
+
+	for field, rule := range labels.Rules(site) {
+		for _, exact := range []bool{true, false} {
+			best := -1
+			for _, pair := range pairs {
+				if ignored[pair.Label] {
+					continue
+				}
+				if rank, ok := rule.Match(pair.Label, exact); ok && (best < 0 || rank < best) {
+					best = rank
+					mapped[field] = pair
+				}
+			}
+			if best >= 0 {
+				break
+			}
+		}
+	}
+	return mapped
+}
+
+// 辞書のどのフィールドにも割り当てられないラベル
+func UnmappedLabels(pairs []LabelValue, site *config.SiteConfig, labels *config.LabelDictionary) []LabelValue {
+	rules := labels.Rules(site)
+	ignored := site.IgnoredLabels()
+
+	var unmapped []LabelValue
+	for _, pair := range pairs {
+		if ignored[pair.Label] {
+			continue
+		}
+		mapped := false
+		for _, rule := range rules {
+			if _, ok := rule.Match(pair.Label, false); ok {
+				mapped = true
+				break
+			}
+			if _, ok := rule.Match(pair.Label, true); ok {
+				mapped = true
+				break
+			}
+		}
+		if !mapped {
+			unmapped = append(unmapped, pair)
+		}
+	}
+	return unmapped
+}
+
+var descriptionLabelRegex = regexp.MustCompile(`^[■□●○◆◇▼▶★☆【\[]?\s*([^：:】\]]{1,20}?)\s*[】\]]?\s*[：:]\s*(.+)$`)
+var htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+var lineBreakTagRegex = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</li>|</div>`)
+
+// descriptionの「ラベル：値」形式の行を集める
+func descriptionLabels(desc string) []LabelValue {
+	desc = lineBreakTagRegex.ReplaceAllString(desc, "\n")
+	desc = htmlTagRegex.ReplaceAllString(desc, "")
+
+	var pairs []LabelValue
+	for _, line := range strings.Split(desc, "\n") {
+		if matches := descriptionLabelRegex.FindStringSubmatch(strings.TrimSpace(line)); len(matches) > 2 {
+			label := config.NormalizeLabel(matches[1])
+			if value := strings.TrimSpace(matches[2]); label != "" && value != "" {
+				pairs = append(pairs, LabelValue{Label: label, Value: value})
+			}
+		}
+	}
+	return pairs
+}
+
+// ページ上の表とJSON-LDのdescriptionからラベルを集める（表が優先）
+func CollectLabels(doc *goquery.Document, site *config.SiteConfig) []LabelValue {
+	pairs := harvestLabels(doc, site)
+	seen := map[string]bool{}
+	for _, pair := range pairs {
+		seen[pair.Label] = true
+	}
+	for _, posting := range findJobPostings(doc) {
+		for _, pair := range descriptionLabels(decodeHTMLEntities(jsonLDText(posting["description"]))) {
+			if !seen[pair.Label] {
+				seen[pair.Label] = true
+				pairs = append(pairs, pair)
+			}
+		}
+	}
+	return pairs
+}
+
+// 表形式のラベルから空のフィールドを埋める
+func applyHarvestedLabels(doc *goquery.Document, site *config.SiteConfig, labels *config.LabelDictionary, w fieldWriter) {
+	mapped := mapLabels(CollectLabels(doc, site), site, labels)
+	for _, name := range job.FieldNames {
+		if pair, ok := mapped[name]; ok {
+			w.fill(name, "「"+pair.Label+"」", pair.Value)
+		}
+	}
+	w.fill("title_original", "copied from name", w.data.Name)
+}
@@ -0,0 +1,176 @@
+package extract
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// 設定やJSON-LDで取れなかった項目を、microdata・RDFa・OpenGraph・<title>から補う
+func extractFallbackMetadata(doc *goquery.Document, w fieldWriter) {
+	data := w.data
+
+	// schema.org JobPosting の microdata / RDFa は JSON-LD と同じ形にして読む
+	type structuredItem struct {
+		strategy string
+		item     map[string]interface{}
+	}
+	var items []structuredItem
+	doc.Find("[itemscope][itemtype]").Each(func(i int, s *goquery.Selection) {
+		if item := microdataItem(s); isJSONLDType(item, "JobPosting") {
+			items = append(items, structuredItem{"microdata", item})
+		}
+	})
+	doc.Find("[typeof]").Each(func(i int, s *goquery.Selection) {
+		if item := rdfaItem(s); isJSONLDType(item, "JobPosting") {
+			items = append(items, structuredItem{"rdfa", item})
+		}
+	})
+	for _, structured := range items {
+		extractFromJobPosting(structured.item, w.as(structured.strategy))
+		// ページ上の本文なので仕事内容として使う
+		w.as(structured.strategy).fill("detail", "description", jsonLDText(structured.item["description"]))
+	}
+
+	// OpenGraph / Twitter Card / meta description
+	meta := w.as("meta")
+	meta.fillName("og:title, twitter:title", metaContent(doc, "og:title", "twitter:title"))
+	meta.fill("detail", "og:description, twitter:description, description", metaContent(doc, "og:description", "twitter:description", "description"))
+	meta.fill("prefecture", "og:region, business:contact_data:region", metaContent(doc, "og:region", "business:contact_data:region"))
+	meta.fill("city", "og:locality, business:contact_data:locality", metaContent(doc, "og:locality", "business:contact_data:locality"))
+	if street := metaContent(doc, "og:street-address", "business:contact_data:street_address"); street != "" {
+		meta.fill("address", "prefecture + city + og:street-address", data.Prefecture+data.City+street)
+	}
+	if data.Prefecture != "" {
+		meta.fill("area", "prefecture + city", data.Prefecture+data.City)
+	}
+
+	// 最後の手段として<title>（「求人名｜サイト名」のサイト名部分は除く）
+	title := strings.TrimSpace(doc.Find("title").First().Text())
+	for _, sep := range []string{"｜", "|", " - ", " – ", " — "} {
+		if idx := strings.Index(title, sep); idx > 0 {
+			title = strings.TrimSpace(title[:idx])
+		}
+	}
+	w.as("title").fillName("<title>", title)
+}
+
+func metaContent(doc *goquery.Document, names ...string) string {
+	for _, name := range names {
+		selector := fmt.Sprintf(`meta[property="%s"], meta[name="%s"]`, name, name)
+		if content, ok := doc.Find(selector).First().Attr("content"); ok {
+			if content = strings.TrimSpace(content); content != "" {
+				return content
+			}
+		}
+	}
+	return ""
+}
+
+// microdata の itemscope 1つ分を JSON-LD と同じ形の map にする
+func microdataItem(scope *goquery.Selection) map[string]interface{} {
+	item := map[string]interface{}{}
+	if itemType := strings.Fields(scope.AttrOr("itemtype", "")); len(itemType) > 0 {
+		item["@type"] = itemType[0]
+	}
+	collectMicrodataProps(scope.Children(), item)
+	return item
+}
+
+func collectMicrodataProps(selection *goquery.Selection, item map[string]interface{}) {
+	selection.Each(func(i int, s *goquery.Selection) {
+		_, scoped := s.Attr("itemscope")
+		if prop, ok := s.Attr("itemprop"); ok {
+			var value interface{}
+			if scoped {
+				value = microdataItem(s)
+			} else {
+				value = structuredDataValue(s)
+			}
+			for _, name := range strings.Fields(prop) {
+				addStructuredProp(item, name, value)
+			}
+		}
+		// 入れ子の itemscope は別のアイテムなので中に入らない
+		if !scoped {
+			collectMicrodataProps(s.Children(), item)
+		}
+	})
+}
+
+// RDFa の typeof 1つ分を JSON-LD と同じ形の map にする
+func rdfaItem(scope *goquery.Selection) map[string]interface{} {
+	item := map[string]interface{}{}
+	if itemType := strings.Fields(scope.AttrOr("typeof", "")); len(itemType) > 0 {
+		item["@type"] = itemType[0]
+	}
+	collectRDFaProps(scope.Children(), item)
+	return item
+}
+
+func collectRDFaProps(selection *goquery.Selection, item map[string]interface{}) {
+	selection.Each(func(i int, s *goquery.Selection) {
+		_, typed := s.Attr("typeof")
+		if prop, ok := s.Attr("property"); ok {
+			var value interface{}
+			if typed {
+				value = rdfaItem(s)
+			} else {
+				value = structuredDataValue(s)
+			}
+			for _, name := range strings.Fields(prop) {
+				addStructuredProp(item, rdfaLocalName(name), value)
+			}
+		}
+		if !typed {
+			collectRDFaProps(s.Children(), item)
+		}
+	})
+}
+
+// "schema:title" や "http://schema.org/title" から "title" を取り出す
+func rdfaLocalName(name string) string {
+	if idx := strings.LastIndexAny(name, ":/#"); idx >= 0 {
+		return name[idx+1:]
+	}
+	return name
+}
+
+func addStructuredProp(item map[string]interface{}, name string, value interface{}) {
+	switch existing := item[name].(type) {
+	case nil:
+		item[name] = value
+	case []interface{}:
+		item[name] = append(existing, value)
+	default:
+		item[name] = []interface{}{existing, value}
+	}
+}
+
+// 要素の種類に応じてプロパティ値を取り出す
+func structuredDataValue(s *goquery.Selection) interface{} {
+	if content, ok := s.Attr("content"); ok {
+		return strings.TrimSpace(content)
+	}
+	var attr string
+	switch goquery.NodeName(s) {
+	case "a", "area", "link":
+		attr = "href"
+	case "img", "audio", "embed", "iframe", "source", "track", "video":
+		attr = "src"
+	case "object":
+		attr = "data"
+	case "time":
+		attr = "datetime"
+	case "data", "meter":
+		attr = "value"
+	}
+	if value, ok := s.Attr(attr); attr != "" && ok {
+		return strings.TrimSpace(value)
+	}
+	if value, ok := s.Attr("resource"); ok {
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(s.Text())
+}
@@ -0,0 +1,96 @@
+package extract
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/goodsun/jobscraper/config"
+	"github.com/goodsun/jobscraper/job"
+)
+
+// Plugin はサイト設定では表せないサイト固有の処理を Go で書くためのもの
+type Plugin struct {
+	Name    string   // サイト設定名と同じ名前にすると、その設定で自動的に使われる
+	Domains []string // URLにこれらを含むページはサイト設定がなくてもこのプラグインを使う
+
+	// サイト設定・JSON-LD・ラベルによる抽出の後に呼ばれ、求人データを直接書き換える
+	PostProcess func(doc *goquery.Document, site *config.SiteConfig, data *job.JobData) error
+}
+
+var (
+	pluginsMu sync.RWMutex
+	plugins   = map[string]Plugin{}
+)
+
+// プラグインを登録する（同じ名前は上書き）
+func RegisterPlugin(plugin Plugin) {
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+	plugins[plugin.Name] = plugin
+}
+
+func HasPlugin(name string) bool {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+	_, ok := plugins[name]
+	return ok
+}
+
+// 登録済みのプラグイン名
+func PluginNames() []string {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+	return pluginNames()
+}
+
+func pluginNames() []string {
+	var names []string
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// URLのドメインに対応するプラグイン名（なければ ""）
+func PluginForURL(url string) string {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+	for _, name := range pluginNames() {
+		for _, domain := range plugins[name].Domains {
+			if domain != "" && strings.Contains(url, domain) {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// サイト設定で使うプラグイン（設定名と同名のもの → plugins に書かれた順）
+func sitePlugins(site *config.SiteConfig) ([]Plugin, []string) {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+
+	var found []Plugin
+	var missing []string
+	seen := map[string]bool{}
+	if plugin, ok := plugins[site.Name]; ok {
+		seen[site.Name] = true
+		found = append(found, plugin)
+	}
+	for _, name := range site.Plugins {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if plugin, ok := plugins[name]; ok {
+			found = append(found, plugin)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	return found, missing
+}
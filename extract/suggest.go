@@ -0,0 +1,441 @@
+package extract
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/antchfx/htmlquery"
+	"golang.org/x/net/html"
+	"golang.org/x/text/width"
+
+	"github.com/goodsun/jobscraper/job"
+)
+
+// 見本のページ1枚と、人が確認したそのページの値
+type Sample struct {
+	Name  string // 表示用（ファイル名など）
+	HTML  string
+	Known map[string]string // フィールド → 値（分からないフィールドは省略）
+}
+
+// セレクターの候補
+type Suggestion struct {
+	Field     string  `json:"field"`
+	Selector  string  `json:"selector"`        // CSSセレクター（goquery）
+	XPath     string  `json:"xpath,omitempty"` // 同じ要素を選ぶXPath（作れなければ空）
+	Kind      string  `json:"kind"`            // "label"（見出しの隣）、"id"、"class"、"path"（位置）
+	Matched   int     `json:"matched"`         // 既知の値と一致したページ数
+	Known     int     `json:"known"`           // 値が分かっているページ数
+	Found     int     `json:"found"`           // 値の分からないページのうち、何か取れたページ数
+	Unknown   int     `json:"unknown"`         // 値の分からないページ数
+	Exactness float64 `json:"exactness"`       // 取れたテキストのうち既知の値が占める割合（平均）
+	Score     float64 `json:"score"`
+}
+
+// 候補の種類ごとの壊れにくさ（見出しを手がかりにしたものほど、レイアウトの変更に強い）
+var suggestionKindWeights = map[string]float64{
+	"label": 1.0,
+	"id":    0.8,
+	"class": 0.6,
+	"path":  0.2,
+}
+
+// SuggestSelectors は見本のページで既知の値を含む要素を探し、フィールドごとにセレクターの候補を
+// スコアの高い順に返す。スコアは全ページで値が一致した割合を中心に、値の分からないページでも
+// 何か取れるか、余計なテキストを含まないか、候補の種類（見出し基準 > id > class > 位置）で決める
+func SuggestSelectors(samples []Sample) (map[string][]Suggestion, []string, error) {
+	docs := make([]*goquery.Document, len(samples))
+	for i, sample := range samples {
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(sample.HTML))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %v", sample.Name, err)
+		}
+		docs[i] = doc
+	}
+
+	var warnings []string
+	suggestions := map[string][]Suggestion{}
+	for _, field := range job.FieldNames {
+		// 既知の値がある各ページで候補を作る
+		seen := map[string]bool{}
+		var candidates []selectorCandidate
+		known := 0
+		for i, sample := range samples {
+			value := normalizeForMatch(sample.Known[field])
+			if value == "" {
+				continue
+			}
+			known++
+			nodes := findValueNodes(docs[i], value)
+			if len(nodes) == 0 {
+				warnings = append(warnings, fmt.Sprintf("%s: %s value %q not found", sample.Name, field, sample.Known[field]))
+				continue
+			}
+			for _, node := range nodes {
+				for _, candidate := range buildCandidates(docs[i], node) {
+					if !seen[candidate.css] {
+						seen[candidate.css] = true
+						candidates = append(candidates, candidate)
+					}
+				}
+			}
+		}
+		if known == 0 {
+			continue
+		}
+
+		var ranked []Suggestion
+		for _, candidate := range candidates {
+			ranked = append(ranked, scoreCandidate(field, candidate, samples, docs))
+		}
+		sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Score > ranked[j].Score })
+		if len(ranked) > 0 {
+			suggestions[field] = ranked
+		}
+	}
+	return suggestions, warnings, nil
+}
+
+// 比較用に値をそろえる（空白を除き、全角英数・半角カナを標準の幅に）
+func normalizeForMatch(value string) string {
+	return width.Fold.String(strings.Join(strings.Fields(value), ""))
+}
+
+var skippedSuggestTags = map[string]bool{"html": true, "head": true, "body": true, "script": true, "style": true, "noscript": true, "template": true}
+
+// 値を含む要素のうち、子要素に値がそのまま含まれていない最も内側のもの（出現順に最大5つ）
+func findValueNodes(doc *goquery.Document, value string) []*html.Node {
+	var nodes []*html.Node
+	doc.Find("*").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		node := s.Get(0)
+		if skippedSuggestTags[node.Data] || !strings.Contains(normalizeForMatch(s.Text()), value) {
+			return true
+		}
+		inner := false
+		s.Children().EachWithBreak(func(_ int, child *goquery.Selection) bool {
+			inner = strings.Contains(normalizeForMatch(child.Text()), value)
+			return !inner
+		})
+		if !inner {
+			nodes = append(nodes, node)
+		}
+		return len(nodes) < 5
+	})
+	return nodes
+}
+
+// CSSとXPathの両方に書き出せるセレクターの1ステップ
+type selectorStep struct {
+	combinator string // 先頭は ""、他は " "（子孫）・" > "（子）・" + "（直後の兄弟）
+	tag        string
+	id         string
+	classes    []string
+	contains   string // :contains() / contains(., ...)
+	nth        int    // :nth-of-type() / [n]（0 なら指定なし）
+}
+
+type selectorCandidate struct {
+	kind  string
+	css   string
+	xpath string
+}
+
+func (step selectorStep) css() string {
+	var css strings.Builder
+	css.WriteString(step.combinator)
+	css.WriteString(step.tag)
+	if step.id != "" {
+		css.WriteString("#" + step.id)
+	}
+	for _, class := range step.classes {
+		css.WriteString("." + class)
+	}
+	if step.contains != "" {
+		css.WriteString(`:contains("` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(step.contains) + `")`)
+	}
+	if step.nth > 0 {
+		css.WriteString(fmt.Sprintf(":nth-of-type(%d)", step.nth))
+	}
+	return css.String()
+}
+
+func (step selectorStep) xpath() (string, bool) {
+	var xp strings.Builder
+	switch step.combinator {
+	case "", " ":
+		xp.WriteString("//")
+	case " > ":
+		xp.WriteString("/")
+	case " + ":
+		xp.WriteString("/following-sibling::*[1][self::" + step.tag + "]")
+	}
+	if step.combinator != " + " {
+		xp.WriteString(step.tag)
+		if step.nth > 0 {
+			xp.WriteString(fmt.Sprintf("[%d]", step.nth))
+		}
+	} else if step.nth > 0 {
+		return "", false
+	}
+	if step.id != "" {
+		xp.WriteString("[@id='" + step.id + "']")
+	}
+	for _, class := range step.classes {
+		xp.WriteString("[contains(concat(' ', normalize-space(@class), ' '), ' " + class + " ')]")
+	}
+	if step.contains != "" {
+		quoted, ok := quoteXPath(step.contains)
+		if !ok {
+			return "", false
+		}
+		xp.WriteString("[contains(., " + quoted + ")]")
+	}
+	return xp.String(), true
+}
+
+func quoteXPath(s string) (string, bool) {
+	if !strings.Contains(s, "'") {
+		return "'" + s + "'", true
+	}
+	if !strings.Contains(s, `"`) {
+		return `"` + s + `"`, true
+	}
+	return "", false
+}
+
+func renderCandidate(kind string, steps []selectorStep) selectorCandidate {
+	candidate := selectorCandidate{kind: kind}
+	var xp strings.Builder
+	xpathOK := true
+	for _, step := range steps {
+		candidate.css += step.css()
+		part, ok := step.xpath()
+		xpathOK = xpathOK && ok
+		xp.WriteString(part)
+	}
+	if xpathOK {
+		candidate.xpath = xp.String()
+	}
+	return candidate
+}
+
+// 自動生成らしいid・classは避ける（数字の連続、css-1x2y3z のようなハッシュ）
+var unstableName = regexp.MustCompile(`[0-9]{3,}|^(css|sc|jsx|emotion|svelte)-|^[a-zA-Z]{1,3}[0-9][a-zA-Z0-9]{4,}$`)
+var cssName = regexp.MustCompile(`^[A-Za-z_][\w-]*$`)
+
+func stableName(name string) bool {
+	return name != "" && len(name) <= 40 && cssName.MatchString(name) && !unstableName.MatchString(name)
+}
+
+func stableClasses(node *html.Node) []string {
+	var classes []string
+	for _, class := range strings.Fields(attrValue(node, "class")) {
+		if stableName(class) {
+			classes = append(classes, class)
+		}
+	}
+	return classes
+}
+
+func attrValue(node *html.Node, key string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func elementStep(node *html.Node) selectorStep {
+	return selectorStep{tag: node.Data, classes: stableClasses(node)}
+}
+
+// 同じ要素名の兄弟の中で何番目か（1始まり）
+func nthOfType(node *html.Node) int {
+	n := 1
+	for sibling := node.PrevSibling; sibling != nil; sibling = sibling.PrevSibling {
+		if sibling.Type == html.ElementNode && sibling.Data == node.Data {
+			n++
+		}
+	}
+	return n
+}
+
+func previousElement(node *html.Node) *html.Node {
+	for sibling := node.PrevSibling; sibling != nil; sibling = sibling.PrevSibling {
+		if sibling.Type == html.ElementNode {
+			return sibling
+		}
+	}
+	return nil
+}
+
+var labelTags = map[string]bool{"th": true, "dt": true, "label": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "span": true, "p": true, "div": true, "b": true, "strong": true}
+
+// 見出しとして使えるテキスト（短く1行のもの）
+func labelText(node *html.Node) string {
+	text := strings.TrimFunc(htmlquery.InnerText(node), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+	})
+	if text == "" || utf8.RuneCountInString(text) > 20 || strings.ContainsAny(text, "\n\t\"") {
+		return ""
+	}
+	return text
+}
+
+// node を選ぶ候補を作る（最初に一致する要素が node になるもののみ）
+func buildCandidates(doc *goquery.Document, node *html.Node) []selectorCandidate {
+	var candidates []selectorCandidate
+	add := func(kind string, steps []selectorStep) {
+		if len(steps) == 0 {
+			return
+		}
+		candidate := renderCandidate(kind, steps)
+		if firstNode(doc, candidate.css) != node {
+			return
+		}
+		if candidate.xpath != "" {
+			if found, err := htmlquery.Query(doc.Nodes[0], candidate.xpath); err != nil || found != node {
+				candidate.xpath = ""
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	// 見出しの直後の要素（dt + dd、th + td など）。値が見出しの隣の要素の中にある場合も
+	ancestor := node
+	for depth := 0; depth < 3 && ancestor != nil && ancestor.Type == html.ElementNode && !skippedSuggestTags[ancestor.Data]; depth++ {
+		if prev := previousElement(ancestor); prev != nil && labelTags[prev.Data] {
+			if label := labelText(prev); label != "" && !strings.Contains(normalizeForMatch(htmlquery.InnerText(node)), normalizeForMatch(label)) {
+				steps := []selectorStep{
+					{tag: prev.Data, contains: label},
+					{combinator: " + ", tag: ancestor.Data},
+				}
+				if ancestor != node {
+					inner := elementStep(node)
+					inner.combinator = " "
+					steps = append(steps, inner)
+				}
+				add("label", steps)
+				break
+			}
+		}
+		ancestor = ancestor.Parent
+	}
+
+	// idを持つ祖先（自身を含む）
+	for ancestor := node; ancestor != nil && ancestor.Type == html.ElementNode; ancestor = ancestor.Parent {
+		if id := attrValue(ancestor, "id"); stableName(id) {
+			steps := []selectorStep{{tag: ancestor.Data, id: id}}
+			if ancestor != node {
+				inner := elementStep(node)
+				inner.combinator = " "
+				steps = append(steps, inner)
+			}
+			add("id", steps)
+			break
+		}
+	}
+
+	// classの組み合わせ（最初に一致するまで親のclassを足す）
+	if len(stableClasses(node)) > 0 {
+		steps := []selectorStep{elementStep(node)}
+		for parent := node.Parent; ; parent = parent.Parent {
+			if firstNode(doc, renderCandidate("class", steps).css) == node {
+				add("class", steps)
+				break
+			}
+			if parent == nil || parent.Type != html.ElementNode || skippedSuggestTags[parent.Data] || len(steps) >= 3 {
+				break
+			}
+			if classes := stableClasses(parent); len(classes) > 0 {
+				steps[0].combinator = " "
+				steps = append([]selectorStep{{tag: parent.Data, classes: classes}}, steps...)
+			}
+		}
+	}
+
+	// body からの位置
+	var steps []selectorStep
+	ancestor = node
+	for ; ancestor != nil && ancestor.Type == html.ElementNode && ancestor.Data != "body" && ancestor.Data != "html"; ancestor = ancestor.Parent {
+		steps = append([]selectorStep{{combinator: " > ", tag: ancestor.Data, nth: nthOfType(ancestor)}}, steps...)
+	}
+	if len(steps) > 0 && ancestor != nil && ancestor.Data == "body" {
+		add("path", append([]selectorStep{{tag: "body"}}, steps...))
+	}
+	return candidates
+}
+
+// セレクターに最初に一致する要素（なければ nil）
+func firstNode(doc *goquery.Document, selector string) *html.Node {
+	if nodes := doc.Find(selector).Nodes; len(nodes) > 0 {
+		return nodes[0]
+	}
+	return nil
+}
+
+func scoreCandidate(field string, candidate selectorCandidate, samples []Sample, docs []*goquery.Document) Suggestion {
+	suggestion := Suggestion{Field: field, Selector: candidate.css, XPath: candidate.xpath, Kind: candidate.kind}
+	exactness := 0.0
+	for i, sample := range samples {
+		text := normalizeForMatch(docs[i].Find(candidate.css).First().Text())
+		known := normalizeForMatch(sample.Known[field])
+		if known == "" {
+			suggestion.Unknown++
+			if text != "" {
+				suggestion.Found++
+			}
+			continue
+		}
+		suggestion.Known++
+		if strings.Contains(text, known) {
+			suggestion.Matched++
+			exactness += float64(len(known)) / float64(len(text))
+		}
+	}
+
+	matchRate := float64(suggestion.Matched) / float64(suggestion.Known)
+	foundRate := 1.0
+	if suggestion.Unknown > 0 {
+		foundRate = float64(suggestion.Found) / float64(suggestion.Unknown)
+	}
+	if suggestion.Matched > 0 {
+		suggestion.Exactness = exactness / float64(suggestion.Matched)
+	}
+	suggestion.Score = 0.55*matchRate + 0.1*foundRate + 0.15*suggestion.Exactness + 0.2*suggestionKindWeights[candidate.kind]
+	return suggestion
+}
+
+// 各フィールドの最上位の候補でサイト設定の下書きを作る
+func DraftConfig(name string, domain string, suggestions map[string][]Suggestion) map[string]interface{} {
+	selectors := map[string]interface{}{}
+	extractors := map[string]interface{}{}
+	for field, ranked := range suggestions {
+		if len(ranked) == 0 {
+			continue
+		}
+		if IsSelectorField(field) {
+			selectors[field] = ranked[0].Selector
+		} else {
+			extractors[field] = map[string]interface{}{"type": "selector", "value": ranked[0].Selector}
+		}
+	}
+
+	draft := map[string]interface{}{"name": name}
+	if domain != "" {
+		draft["domain"] = domain
+	}
+	if len(selectors) > 0 {
+		draft["selectors"] = selectors
+	}
+	if len(extractors) > 0 {
+		draft["extractors"] = extractors
+	}
+	return draft
+}
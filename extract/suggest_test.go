@@ -0,0 +1,79 @@
+package extract
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goodsun/jobscraper/output"
+)
+
+// 見出しの表と本文を持つ求人ページ
+func suggestPage(title string, price string, hours string) string {
+	return `<html><body><div class="main"><h1 class="title">` + title + `</h1>` +
+		`<table><tr><th>給与</th><td>` + price + `</td></tr><tr><th>勤務時間</th><td>` + hours + `</td></tr></table>` +
+		`<div class="box"><p>` + hours + `</p></div></div></body></html>`
+}
+
+func TestSuggestSelectors(t *testing.T) {
+	samples := []Sample{
+		{Name: "a", HTML: suggestPage("看護師募集", "月給30万円", "9:00-18:00"), Known: map[string]string{"name": "看護師募集", "price": "月給30万円", "working_hours": "9:00-18:00"}},
+		{Name: "b", HTML: suggestPage("薬剤師募集", "月給40万円", "8:30-17:30"), Known: map[string]string{"name": "薬剤師募集", "price": "月給40万円"}},
+	}
+	suggestions, warnings, err := SuggestSelectors(samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) > 0 {
+		t.Errorf("warnings = %q; want none", warnings)
+	}
+
+	tests := []struct {
+		field    string
+		selector string
+		kind     string
+	}{
+		{"name", "h1.title", "class"},
+		// 見出しの隣は位置より先
+		{"price", `th:contains("給与") + td`, "label"},
+		{"working_hours", `th:contains("勤務時間") + td`, "label"},
+	}
+	for _, tt := range tests {
+		ranked := suggestions[tt.field]
+		if len(ranked) == 0 {
+			t.Errorf("%s: no suggestions", tt.field)
+			continue
+		}
+		if ranked[0].Selector != tt.selector || ranked[0].Kind != tt.kind {
+			t.Errorf("%s: top suggestion = %q (%s); want %q (%s)", tt.field, ranked[0].Selector, ranked[0].Kind, tt.selector, tt.kind)
+		}
+	}
+}
+
+func TestDraftConfig(t *testing.T) {
+	suggestions := map[string][]Suggestion{
+		"name":     {{Selector: "h1.title"}},
+		"position": {{Selector: "div.box > p"}, {Selector: "p"}},
+		"detail":   nil,
+	}
+	draft := DraftConfig("sample", "www.sample.co.jp", suggestions)
+	want := map[string]interface{}{
+		"name":      "sample",
+		"domain":    "www.sample.co.jp",
+		"selectors": map[string]interface{}{"name": "h1.title"},
+		// selectors で読まれないフィールドは extractors に書く
+		"extractors": map[string]interface{}{"position": map[string]interface{}{"type": "selector", "value": "div.box > p"}},
+	}
+	if !reflect.DeepEqual(draft, want) {
+		t.Errorf("DraftConfig = %v; want %v", draft, want)
+	}
+
+	// 書き出した下書きのセレクターはエスケープしない
+	data, err := output.ConfigJSON(draft)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"div.box > p"`) {
+		t.Errorf("ConfigJSON escaped the selector:\n%s", data)
+	}
+}
@@ -0,0 +1,210 @@
+package extract
+
+import "github.com/goodsun/jobscraper/job"
+
+// Trace はフィールドごとに、試した抽出方法・候補の値・採用した値を記録したもの（--explain 用）。
+// Report.Trace に NewTrace() を入れて Extract を呼んだ場合のみ記録する
+type Trace struct {
+	Fields []*FieldTrace `json:"fields"` // job.FieldNames の順（正規化で変わった構造化データのフィールドは後ろに付ける）
+}
+
+// フィールド1つ分の経過
+type FieldTrace struct {
+	Field         string          `json:"field"`
+	Value         string          `json:"value"`                   // 正規化後の最終的な値
+	Candidates    []Candidate     `json:"candidates,omitempty"`    // 試した順
+	Normalization []NormalizeStep `json:"normalization,omitempty"` // 正規化で変わった値（適用した順）
+
+	holder int // 値を入れた候補（なければ -1）
+}
+
+// 抽出方法1つが出した候補の値
+type Candidate struct {
+	Strategy string `json:"strategy"`         // "json-ld"、"description"、"selector"、"extractor"、"labels"、"microdata"、"rdfa"、"meta"、"title"、"plugin"
+	Source   string `json:"source,omitempty"` // JSON-LDのキー、セレクター、ラベルなど
+	Value    string `json:"value"`
+	Used     bool   `json:"used"`   // 抽出の結果として採用された候補
+	Reason   string `json:"reason"` // 採用した・しなかった理由
+}
+
+// 正規化1段階分の変化
+type NormalizeStep struct {
+	Normalizer string `json:"normalizer"`
+	Before     string `json:"before"`
+	After      string `json:"after"`
+}
+
+func NewTrace() *Trace {
+	trace := &Trace{}
+	for _, name := range job.FieldNames {
+		trace.Fields = append(trace.Fields, &FieldTrace{Field: name, holder: -1})
+	}
+	return trace
+}
+
+// フィールド名の経過（未知のフィールドは nil）
+func (t *Trace) Field(name string) *FieldTrace {
+	if t == nil {
+		return nil
+	}
+	for _, field := range t.Fields {
+		if field.Field == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// 候補の値をフィールドに入れたことを記録する（前の候補は上書きされたことになる）
+func (t *Trace) applied(name string, strategy string, source string, value string) {
+	field := t.Field(name)
+	if field == nil {
+		return
+	}
+	reason := "first value found"
+	if field.holder >= 0 {
+		previous := &field.Candidates[field.holder]
+		previous.Used = false
+		previous.Reason = "overridden by " + strategy
+		reason = "overrides " + previous.Strategy
+	}
+	field.holder = len(field.Candidates)
+	field.Candidates = append(field.Candidates, Candidate{Strategy: strategy, Source: source, Value: value, Used: true, Reason: reason})
+}
+
+// フィールドに値があったため使わなかった候補を記録する
+func (t *Trace) skipped(name string, strategy string, source string, value string) {
+	field := t.Field(name)
+	if field == nil {
+		return
+	}
+	reason := "not used: field was already set"
+	if field.holder >= 0 {
+		reason = "not used: already set by " + field.Candidates[field.holder].Strategy
+	}
+	field.Candidates = append(field.Candidates, Candidate{Strategy: strategy, Source: source, Value: value, Reason: reason})
+}
+
+// 値が取れなかった設定（セレクター・extractor）を記録する
+func (t *Trace) missed(name string, strategy string, source string, reason string) {
+	if field := t.Field(name); field != nil {
+		field.Candidates = append(field.Candidates, Candidate{Strategy: strategy, Source: source, Reason: reason})
+	}
+}
+
+// 値を消した処理を記録する
+func (t *Trace) cleared(name string, strategy string, source string) {
+	field := t.Field(name)
+	if field == nil {
+		return
+	}
+	if field.holder >= 0 {
+		field.Candidates[field.holder].Used = false
+		field.Candidates[field.holder].Reason = "cleared by " + strategy
+		field.holder = -1
+	}
+	field.Candidates = append(field.Candidates, Candidate{Strategy: strategy, Source: source, Reason: "cleared the value"})
+}
+
+// 正規化による変化を記録する（構造化データのフィールドは最初の変化で加える）
+func (t *Trace) Normalized(name string, normalizer string, before string, after string) {
+	if t == nil {
+		return
+	}
+	field := t.Field(name)
+	if field == nil && job.IsStructuredField(name) {
+		field = &FieldTrace{Field: name, holder: -1}
+		t.Fields = append(t.Fields, field)
+	}
+	if field != nil {
+		field.Normalization = append(field.Normalization, NormalizeStep{Normalizer: normalizer, Before: before, After: after})
+	}
+}
+
+// 最終的な値を記録する
+func (t *Trace) Finish(data *job.JobData) {
+	if t == nil {
+		return
+	}
+	fields, structured := data.Fields(), data.StructuredFields()
+	for _, field := range t.Fields {
+		if value, ok := fields[field.Field]; ok {
+			field.Value = *value
+		} else {
+			field.Value = structured[field.Field]
+		}
+	}
+}
+
+// 求人データへの書き込み。trace があれば、使わなかった候補も理由付きで記録する
+type fieldWriter struct {
+	data     *job.JobData
+	fields   map[string]*string
+	trace    *Trace
+	strategy string
+}
+
+func newFieldWriter(data *job.JobData, trace *Trace, strategy string) fieldWriter {
+	return fieldWriter{data: data, fields: data.Fields(), trace: trace, strategy: strategy}
+}
+
+// 抽出方法の名前を変えた writer
+func (w fieldWriter) as(strategy string) fieldWriter {
+	w.strategy = strategy
+	return w
+}
+
+// フィールドが空の場合だけ値を入れる（値が空なら何もしない）
+func (w fieldWriter) fill(field string, source string, value string) bool {
+	target, ok := w.fields[field]
+	if !ok || value == "" {
+		return false
+	}
+	if *target != "" {
+		w.trace.skipped(field, w.strategy, source, value)
+		return false
+	}
+	*target = value
+	w.trace.applied(field, w.strategy, source, value)
+	return true
+}
+
+// 値があればフィールドを上書きする
+func (w fieldWriter) set(field string, source string, value string) bool {
+	target, ok := w.fields[field]
+	if !ok || value == "" {
+		return false
+	}
+	*target = value
+	w.trace.applied(field, w.strategy, source, value)
+	return true
+}
+
+// 値が取れなかったことを記録する
+func (w fieldWriter) missed(field string, source string, reason string) {
+	w.trace.missed(field, w.strategy, source, reason)
+}
+
+// 求人名が空の場合だけ、求人名と元のタイトルを入れる
+func (w fieldWriter) fillName(source string, value string) {
+	if w.fill("name", source, value) {
+		w.set("title_original", source, value)
+	}
+}
+
+// 値を書き換えた処理（プラグインなど）の変更を、元の値と比べて記録する
+func (w fieldWriter) recordChanges(before job.JobData, source string) {
+	if w.trace == nil {
+		return
+	}
+	previous := before.Fields()
+	for _, name := range job.FieldNames {
+		if value := *w.fields[name]; value != *previous[name] {
+			if value == "" {
+				w.trace.cleared(name, w.strategy, source)
+				continue
+			}
+			w.trace.applied(name, w.strategy, source, value)
+		}
+	}
+}
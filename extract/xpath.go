@@ -0,0 +1,81 @@
+package extract
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/antchfx/htmlquery"
+	"golang.org/x/net/html"
+
+	"github.com/goodsun/jobscraper/config"
+	"github.com/goodsun/jobscraper/job"
+)
+
+func extractByXPath(doc *html.Node, xpath string) string {
+	if xpath == "" {
+		return ""
+	}
+
+	nodes, err := htmlquery.QueryAll(doc, xpath)
+	if err != nil || len(nodes) == 0 {
+		return ""
+	}
+
+	return strings.TrimSpace(htmlquery.InnerText(nodes[0]))
+}
+
+// xpath 型の extractor（goquery と同じDOMをXPathでたどる）
+func extractWithExtractorXPath(doc *goquery.Document, extractor config.ExtractorConfig, report *Report) string {
+	if len(doc.Nodes) == 0 {
+		return ""
+	}
+	nodes, err := htmlquery.QueryAll(doc.Nodes[0], extractor.Value)
+	if err != nil {
+		report.warnf("invalid XPath %q: %v", extractor.Value, err)
+		return ""
+	}
+	if extractor.Index < 0 || extractor.Index >= len(nodes) {
+		return ""
+	}
+	node := nodes[extractor.Index]
+	if extractor.Attr != "" && extractor.Attr != "text" {
+		return strings.TrimSpace(htmlquery.SelectAttr(node, extractor.Attr))
+	}
+	return strings.TrimSpace(htmlquery.InnerText(node))
+}
+
+// XPath は旧形式のXPath設定で全フィールドを取り出す
+func XPath(htmlContent string, xpaths *config.XPathConfig) (*job.JobData, error) {
+	doc, err := htmlquery.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil, err
+	}
+
+	data := &job.JobData{
+		Name:           extractByXPath(doc, xpaths.Name),
+		Price:          extractByXPath(doc, xpaths.Price),
+		Area:           extractByXPath(doc, xpaths.Area),
+		Access:         extractByXPath(doc, xpaths.Access),
+		Address:        extractByXPath(doc, xpaths.Address),
+		City:           extractByXPath(doc, xpaths.City),
+		Prefecture:     extractByXPath(doc, xpaths.Prefecture),
+		Contract:       extractByXPath(doc, xpaths.Contract),
+		Dept:           extractByXPath(doc, xpaths.Dept),
+		Detail:         extractByXPath(doc, xpaths.Detail),
+		FacilityName:   extractByXPath(doc, xpaths.FacilityName),
+		FacilityType:   extractByXPath(doc, xpaths.FacilityType),
+		Holiday:        extractByXPath(doc, xpaths.Holiday),
+		License:        extractByXPath(doc, xpaths.License),
+		Occupation:     extractByXPath(doc, xpaths.Occupation),
+		Position:       extractByXPath(doc, xpaths.Position),
+		RequiredSkill:  extractByXPath(doc, xpaths.RequiredSkill),
+		StaffComment:   extractByXPath(doc, xpaths.StaffComment),
+		Station:        extractByXPath(doc, xpaths.Station),
+		WelfareProgram: extractByXPath(doc, xpaths.WelfareProgram),
+		WorkingHours:   extractByXPath(doc, xpaths.WorkingHours),
+		WorkingStyle:   extractByXPath(doc, xpaths.WorkingStyle),
+		TitleOriginal:  extractByXPath(doc, xpaths.TitleOriginal),
+	}
+
+	return data, nil
+}
@@ -0,0 +1,218 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// デバッグ用アーティファクト（--artifacts 指定時のみ出力）
+type ArtifactWriter struct {
+	Dir     string
+	mu      sync.Mutex
+	entries []HAREntry
+	err     error
+}
+
+type artifactsKey struct{}
+
+// 取得処理にアーティファクトの保存先を渡す
+func WithArtifacts(ctx context.Context, artifacts *ArtifactWriter) context.Context {
+	return context.WithValue(ctx, artifactsKey{}, artifacts)
+}
+
+// WithArtifacts で設定した保存先（なければ nil）
+func ArtifactsFromContext(ctx context.Context) *ArtifactWriter {
+	artifacts, _ := ctx.Value(artifactsKey{}).(*ArtifactWriter)
+	return artifacts
+}
+
+// HAR 形式に近いリクエストログ
+type HAREntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Error           string      `json:"_error,omitempty"`
+}
+
+type HARRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers []HARHeader `json:"headers"`
+}
+
+type HARResponse struct {
+	Status     int         `json:"status"`
+	StatusText string      `json:"statusText"`
+	Headers    []HARHeader `json:"headers"`
+	MimeType   string      `json:"mimeType"`
+}
+
+type HARHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+var unsafeDirChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// URLから求人ごとのフォルダ名を決める（同じURLなら常に同じ名前）
+func ArtifactDirName(rawURL string) string {
+	name := rawURL
+	if u, err := neturl.Parse(rawURL); err == nil && u.Host != "" {
+		name = u.Host + u.Path
+		if u.RawQuery != "" {
+			name += "_" + u.RawQuery
+		}
+	}
+	name = strings.Trim(unsafeDirChars.ReplaceAllString(name, "_"), "_.")
+	if len(name) > 80 {
+		name = name[:80]
+	}
+	sum := sha1.Sum([]byte(rawURL))
+	return fmt.Sprintf("%s_%x", name, sum[:4])
+}
+
+func NewArtifactWriter(baseDir string, rawURL string) (*ArtifactWriter, error) {
+	dir := filepath.Join(baseDir, ArtifactDirName(rawURL))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &ArtifactWriter{Dir: dir}, nil
+}
+
+// 書き込みに失敗しても取得処理は続けるので、最初のエラーは Err で確認する
+func (a *ArtifactWriter) WriteFile(name string, data []byte) error {
+	err := os.WriteFile(filepath.Join(a.Dir, name), data, 0644)
+	a.setErr(err)
+	return err
+}
+
+func (a *ArtifactWriter) setErr(err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.err == nil {
+		a.err = err
+	}
+}
+
+// これまでの書き込みで最初に起きたエラー
+func (a *ArtifactWriter) Err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.err
+}
+
+func (a *ArtifactWriter) WriteJSON(name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		a.setErr(err)
+		return err
+	}
+	return a.WriteFile(name, data)
+}
+
+func (a *ArtifactWriter) AddEntry(entry HAREntry) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.entries = append(a.entries, entry)
+}
+
+// リクエストログを requests.har に書き出す（取得が終わってから呼ぶ）
+func (a *ArtifactWriter) Close() error {
+	a.mu.Lock()
+	entries := append([]HAREntry{}, a.entries...)
+	a.mu.Unlock()
+	har := map[string]interface{}{
+		"log": map[string]interface{}{
+			"version": "1.2",
+			"creator": map[string]string{"name": "jobscraper", "version": "1.0"},
+			"entries": entries,
+		},
+	}
+	return a.WriteJSON("requests.har", har)
+}
+
+// パーサーが解釈したDOMを dom.html に書き出す
+func (a *ArtifactWriter) WriteDOM(htmlContent string) error {
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		a.setErr(err)
+		return err
+	}
+	var dom bytes.Buffer
+	if err := html.Render(&dom, doc); err != nil {
+		a.setErr(err)
+		return err
+	}
+	return a.WriteFile("dom.html", dom.Bytes())
+}
+
+// レスポンスヘッダーを headers.json に書き出す
+func (a *ArtifactWriter) WriteHeaders(url string, status int, statusText string, headers interface{}) error {
+	return a.WriteJSON("headers.json", map[string]interface{}{
+		"url":         url,
+		"status":      status,
+		"status_text": statusText,
+		"headers":     headers,
+	})
+}
+
+func harHeaders(header http.Header) []HARHeader {
+	headers := []HARHeader{}
+	for name, values := range header {
+		for _, value := range values {
+			headers = append(headers, HARHeader{Name: name, Value: value})
+		}
+	}
+	sort.Slice(headers, func(i, j int) bool { return headers[i].Name < headers[j].Name })
+	return headers
+}
+
+// リダイレクトを含む全リクエストを記録するTransport
+type recordingTransport struct {
+	base      http.RoundTripper
+	artifacts *ArtifactWriter
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	started := time.Now()
+	resp, err := t.base.RoundTrip(req)
+
+	entry := HAREntry{
+		StartedDateTime: started,
+		Time:            float64(time.Since(started).Microseconds()) / 1000,
+		Request: HARRequest{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Headers: harHeaders(req.Header),
+		},
+		Response: HARResponse{Headers: []HARHeader{}},
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Response = HARResponse{
+			Status:     resp.StatusCode,
+			StatusText: http.StatusText(resp.StatusCode),
+			Headers:    harHeaders(resp.Header),
+			MimeType:   resp.Header.Get("Content-Type"),
+		}
+	}
+	t.artifacts.AddEntry(entry)
+
+	return resp, err
+}
@@ -0,0 +1,118 @@
+// Package fetch は求人ページの取得（HTTP・ヘッドレスブラウザ・ローカルファイル）を行う。
+package fetch
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// Fetcher はリクエストに対するページのHTMLを返す
+type Fetcher interface {
+	Fetch(ctx context.Context, req *http.Request) (string, error)
+}
+
+// HTTPFetcher は通常のHTTPリクエストでHTMLを取得する
+type HTTPFetcher struct {
+	Client *http.Client // nil なら http.DefaultClient
+}
+
+// context にアーティファクトが設定されていれば、取得内容とリクエストログも保存する
+func (f *HTTPFetcher) Fetch(ctx context.Context, req *http.Request) (string, error) {
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	artifacts := ArtifactsFromContext(ctx)
+	if artifacts != nil {
+		recording := *client
+		base := recording.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		recording.Transport = &recordingTransport{base: base, artifacts: artifacts}
+		client = &recording
+	}
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if artifacts != nil {
+		// 受信したままのHTMLとレスポンスヘッダーを保存
+		artifacts.WriteFile("raw.html", body)
+		artifacts.WriteHeaders(resp.Request.URL.String(), resp.StatusCode, resp.Status, resp.Header)
+	}
+
+	return string(body), nil
+}
+
+// URLからHTMLを取得する
+func Get(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	return (&HTTPFetcher{}).Fetch(ctx, req)
+}
+
+func IsURL(input string) bool {
+	return strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://")
+}
+
+// ローカルに保存したHTMLを読む
+func ReadFile(ctx context.Context, path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if artifacts := ArtifactsFromContext(ctx); artifacts != nil {
+		artifacts.WriteFile("raw.html", content)
+	}
+	return string(content), nil
+}
+
+// URLならHTTPで取得し、それ以外はローカルファイルとして読む
+func Input(ctx context.Context, input string) (string, error) {
+	if IsURL(input) {
+		return Get(ctx, input)
+	}
+	return ReadFile(ctx, input)
+}
+
+func ConvertEncoding(content string, encoding string) (string, error) {
+	if encoding == "" || encoding == "utf-8" {
+		return content, nil
+	}
+
+	var decoder transform.Transformer
+	switch strings.ToLower(encoding) {
+	case "shift_jis", "sjis":
+		decoder = japanese.ShiftJIS.NewDecoder()
+	case "euc-jp":
+		decoder = japanese.EUCJP.NewDecoder()
+	case "iso-2022-jp":
+		decoder = japanese.ISO2022JP.NewDecoder()
+	default:
+		return content, nil // 未対応エンコーディングの場合はそのまま返す
+	}
+
+	result, _, err := transform.String(decoder, content)
+	if err != nil {
+		return content, err // エラーの場合は元の文字列を返す
+	}
+
+	return result, nil
+}
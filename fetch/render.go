@@ -0,0 +1,184 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// BrowserFetcher はヘッドレスChromeでページをレンダリングし、レンダリング後のDOMを返す
+type BrowserFetcher struct {
+	Timeout time.Duration // 全体のタイムアウト（省略時60秒）
+	Wait    time.Duration // body の準備後に動的コンテンツを待つ時間（省略時3秒）
+}
+
+// Fetch はリクエストのURLだけを使う（ヘッダーはブラウザが付ける）
+func (f *BrowserFetcher) Fetch(ctx context.Context, req *http.Request) (string, error) {
+	timeout, wait := f.Timeout, f.Wait
+	if timeout == 0 {
+		timeout = 60 * time.Second
+	}
+	if wait == 0 {
+		wait = 3 * time.Second
+	}
+	artifacts := ArtifactsFromContext(ctx)
+
+	// ブラウザのコンテキストを作る
+	ctx, cancel := chromedp.NewContext(ctx)
+	defer cancel()
+
+	// 全体のタイムアウト
+	ctx, cancel = context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// リクエストログ用に通信を記録する
+	var recorder *networkRecorder
+	if artifacts != nil {
+		recorder = newNetworkRecorder()
+		chromedp.ListenTarget(ctx, recorder.handle)
+		if err := chromedp.Run(ctx, network.Enable()); err != nil {
+			return "", fmt.Errorf("failed to enable network events: %v", err)
+		}
+	}
+
+	// ページを開いて読み込みを待つ
+	err := chromedp.Run(ctx,
+		chromedp.Navigate(req.URL.String()),
+		chromedp.WaitReady("body"),
+		chromedp.Sleep(wait), // 動的コンテンツの読み込みを待つ
+	)
+	if recorder != nil {
+		recorder.save(ctx, artifacts)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to navigate: %v", err)
+	}
+
+	var htmlContent string
+	if err := chromedp.Run(ctx, chromedp.OuterHTML("html", &htmlContent)); err != nil {
+		return "", fmt.Errorf("failed to get rendered DOM: %v", err)
+	}
+
+	// スクリーンショットとレンダリング後のDOMを保存
+	if artifacts != nil {
+		var buf []byte
+		if err := chromedp.Run(ctx, chromedp.FullScreenshot(&buf, 90)); err != nil {
+			artifacts.setErr(fmt.Errorf("failed to take screenshot: %v", err))
+		} else {
+			artifacts.WriteFile("screenshot.png", buf)
+		}
+		artifacts.WriteFile("dom.html", []byte(htmlContent))
+	}
+
+	return htmlContent, nil
+}
+
+// Render は標準の設定のヘッドレスChromeでページを読み込む
+func Render(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	return (&BrowserFetcher{}).Fetch(ctx, req)
+}
+
+func cdpHeaders(header network.Headers) []HARHeader {
+	headers := []HARHeader{}
+	for name, value := range header {
+		headers = append(headers, HARHeader{Name: name, Value: fmt.Sprint(value)})
+	}
+	sort.Slice(headers, func(i, j int) bool { return headers[i].Name < headers[j].Name })
+	return headers
+}
+
+// DevTools のネットワークイベントからリクエストログを作る
+type networkRecorder struct {
+	mu          sync.Mutex
+	entries     map[network.RequestID]*HAREntry
+	order       []network.RequestID
+	documentID  network.RequestID
+	documentRes *network.Response
+}
+
+func newNetworkRecorder() *networkRecorder {
+	return &networkRecorder{entries: map[network.RequestID]*HAREntry{}}
+}
+
+func (r *networkRecorder) handle(ev interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch ev := ev.(type) {
+	case *network.EventRequestWillBeSent:
+		started := time.Now()
+		if ev.WallTime != nil {
+			started = ev.WallTime.Time()
+		}
+		if _, ok := r.entries[ev.RequestID]; !ok {
+			r.order = append(r.order, ev.RequestID)
+		}
+		r.entries[ev.RequestID] = &HAREntry{
+			StartedDateTime: started,
+			Request: HARRequest{
+				Method:  ev.Request.Method,
+				URL:     ev.Request.URL,
+				Headers: cdpHeaders(ev.Request.Headers),
+			},
+			Response: HARResponse{Headers: []HARHeader{}},
+		}
+		if ev.Type == network.ResourceTypeDocument && r.documentID == "" {
+			r.documentID = ev.RequestID
+		}
+	case *network.EventResponseReceived:
+		entry, ok := r.entries[ev.RequestID]
+		if !ok {
+			return
+		}
+		entry.Response = HARResponse{
+			Status:     int(ev.Response.Status),
+			StatusText: ev.Response.StatusText,
+			Headers:    cdpHeaders(ev.Response.Headers),
+			MimeType:   ev.Response.MimeType,
+		}
+		entry.Time = float64(time.Since(entry.StartedDateTime).Microseconds()) / 1000
+		if ev.RequestID == r.documentID {
+			r.documentRes = ev.Response
+		}
+	case *network.EventLoadingFailed:
+		if entry, ok := r.entries[ev.RequestID]; ok {
+			entry.Error = ev.ErrorText
+		}
+	}
+}
+
+// リクエストログ・受信したままのHTML・レスポンスヘッダーを保存する
+func (r *networkRecorder) save(ctx context.Context, artifacts *ArtifactWriter) {
+	r.mu.Lock()
+	for _, id := range r.order {
+		artifacts.AddEntry(*r.entries[id])
+	}
+	documentID, documentRes := r.documentID, r.documentRes
+	r.mu.Unlock()
+
+	if documentRes != nil {
+		artifacts.WriteHeaders(documentRes.URL, int(documentRes.Status), documentRes.StatusText, documentRes.Headers)
+	}
+	if documentID != "" {
+		err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+			body, err := network.GetResponseBody(documentID).Do(ctx)
+			if err != nil {
+				return err
+			}
+			return artifacts.WriteFile("raw.html", body)
+		}))
+		if err != nil {
+			artifacts.setErr(fmt.Errorf("failed to get raw document: %v", err))
+		}
+	}
+}
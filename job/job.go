@@ -0,0 +1,412 @@
+// Package job は求人データの共通フォーマットを定義する。
+package job
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// 汎用的なフィールド定義
+type JobData struct {
+	Name           string `json:"name"`
+	Price          string `json:"price"`
+	Area           string `json:"area"`
+	Access         string `json:"access"`
+	Address        string `json:"address"`
+	City           string `json:"city"`
+	Prefecture     string `json:"prefecture"`
+	Ward           string `json:"ward"`            // 政令指定都市の区
+	Street         string `json:"street"`          // 市区町村より後ろの住所
+	PostalCode     string `json:"postal_code"`     // 郵便番号（"123-4567"）
+	PrefectureCode string `json:"prefecture_code"` // 都道府県コード（2桁）
+	CityCode       string `json:"city_code"`       // 全国地方公共団体コード（6桁）
+	Contract       string `json:"contract"`
+	Dept           string `json:"dept"`
+	Detail         string `json:"detail"`
+	FacilityName   string `json:"facility_name"`
+	FacilityType   string `json:"facility_type"`
+	Holiday        string `json:"holiday"`
+	License        string `json:"license"`
+	Occupation     string `json:"occupation"`
+	Position       string `json:"position"`
+	RequiredSkill  string `json:"required_skill"`
+	StaffComment   string `json:"staff_comment"`
+	Station        string `json:"station"`
+	NearestStation string `json:"nearest_station"` // 最寄り駅と所要時間（"中野坂上駅 徒歩7分"）
+	WelfareProgram string `json:"welfare_program"`
+	WorkingHours   string `json:"working_hours"`
+	WorkingStyle   string `json:"working_style"`
+	TitleOriginal  string `json:"title_original"`
+	DatePosted     string `json:"date_posted"`
+	ValidThrough   string `json:"valid_through"`
+	Identifier     string `json:"identifier"`
+	DirectApply    string `json:"direct_apply"`
+
+	// 正規化で文字列から取り出した構造化データ（元の文字列のフィールドもそのまま残す）
+	Transit         []Transit        `json:"transit,omitempty"`          // Access・Station の駅ごとの交通手段
+	EmploymentTypes []string         `json:"employment_types,omitempty"` // Contract の雇用形態（EmploymentTypeNames の値）
+	Schedule        *Schedule        `json:"schedule,omitempty"`         // WorkingHours・WorkingStyle などの勤務時間・勤務形態
+	Holidays        *Holidays        `json:"holidays,omitempty"`         // Holiday・WelfareProgram・Detail の休日・休暇
+	WelfareTags     []string         `json:"welfare_tags,omitempty"`     // WelfareProgram などの福利厚生のタグ（configs/welfare.json）
+	Occupations     []Classification `json:"occupations,omitempty"`      // Occupation などの職種（OccupationTypes の値）
+	Licenses        []License        `json:"licenses,omitempty"`         // License・RequiredSkill の資格（LicenseTypes の値）
+	ExperienceYears *int             `json:"experience_years,omitempty"` // 必要な経験年数（「未経験可」「経験不問」は0、記載がなければなし）
+	Departments     []string         `json:"departments,omitempty"`      // Dept の診療科目（DepartmentNames の値。一覧にないものは元の言葉）
+}
+
+// 雇用形態の正規の値（この順に並べる）
+var EmploymentTypeNames = []string{
+	"正社員",
+	"契約社員",
+	"パート・アルバイト",
+	"派遣",
+	"紹介予定派遣",
+	"業務委託",
+	"日雇い・単発",
+	"インターン",
+	"ボランティア",
+	"その他",
+}
+
+// schema.org の employmentType → 雇用形態
+var SchemaOrgEmploymentTypes = map[string]string{
+	"FULL_TIME":  "正社員",
+	"PART_TIME":  "パート・アルバイト",
+	"CONTRACTOR": "業務委託", // 個人で請け負う人
+	"CONTRACT":   "契約社員", // schema.org にはないが使っているサイトがある
+	"TEMPORARY":  "派遣",
+	"INTERN":     "インターン",
+	"VOLUNTEER":  "ボランティア",
+	"PER_DIEM":   "日雇い・単発",
+	"OTHER":      "その他",
+}
+
+// 勤務形態の正規の値（この順に並べる）
+var WorkingStyleNames = []string{
+	"日勤のみ",
+	"日勤のみ可", // 夜勤があり、日勤のみも選べる
+	"夜勤専従",
+	"2交替",
+	"3交替",
+	"シフト制",
+	"変形労働時間制",
+	"フレックスタイム制",
+	"時短勤務",
+}
+
+// 勤務時間・勤務形態
+type Schedule struct {
+	Shifts        []Shift  `json:"shifts,omitempty"`
+	Days          []string `json:"days,omitempty"`           // 勤務する曜日（"月"〜"日"）
+	Styles        []string `json:"styles,omitempty"`         // WorkingStyleNames の値
+	NightShift    bool     `json:"night_shift"`              // 夜勤・当直がある
+	OnCall        bool     `json:"on_call"`                  // オンコールがある
+	DayOnly       bool     `json:"day_only"`                 // 日勤のみ（夜勤の記載がなく、勤務時間がすべて日中の場合も含む）
+	OvertimeHours *float64 `json:"overtime_hours,omitempty"` // 月の残業時間（「残業なし」は0、記載がなければなし）
+}
+
+// 勤務時間帯1つ分
+type Shift struct {
+	Start        string `json:"start"`                   // "08:30"
+	End          string `json:"end"`                     // "17:30"
+	Overnight    bool   `json:"overnight,omitempty"`     // 終わりが翌日
+	BreakMinutes int    `json:"break_minutes,omitempty"` // 休憩（分）
+}
+
+// 診療科目の正規の値（この順に並べ、一覧にない科目は後ろに付ける）
+var DepartmentNames = []string{
+	"内科",
+	"総合診療科",
+	"呼吸器内科",
+	"循環器内科",
+	"消化器内科",
+	"腎臓内科",
+	"糖尿病・内分泌内科",
+	"血液内科",
+	"脳神経内科",
+	"感染症内科",
+	"腫瘍内科",
+	"リウマチ科",
+	"アレルギー科",
+	"心療内科",
+	"精神科",
+	"小児科",
+	"外科",
+	"呼吸器外科",
+	"心臓血管外科",
+	"消化器外科",
+	"乳腺外科",
+	"肛門外科",
+	"脳神経外科",
+	"整形外科",
+	"形成外科",
+	"美容外科",
+	"皮膚科",
+	"泌尿器科",
+	"産婦人科",
+	"産科",
+	"婦人科",
+	"眼科",
+	"耳鼻咽喉科",
+	"リハビリテーション科",
+	"放射線科",
+	"麻酔科",
+	"病理診断科",
+	"救急科",
+	"人工透析",
+	"健診・人間ドック",
+	"緩和ケア",
+	"訪問診療",
+	"歯科",
+	"小児歯科",
+	"矯正歯科",
+	"歯科口腔外科",
+}
+
+// 休暇の正規の値（この順に並べる）
+var LeaveNames = []string{
+	"有給休暇",
+	"夏季休暇",
+	"冬季休暇",
+	"年末年始休暇",
+	"慶弔休暇",
+	"産前産後休暇",
+	"育児休業",
+	"介護休業",
+	"子の看護休暇",
+	"リフレッシュ休暇",
+	"誕生日休暇",
+	"特別休暇",
+}
+
+// 休日・休暇
+type Holidays struct {
+	AnnualDays int      `json:"annual_days,omitempty"` // 年間休日（日）
+	WeeklyRest string   `json:"weekly_rest,omitempty"` // "完全週休2日"、"週休2日"、"4週8休" など
+	DaysOff    []string `json:"days_off,omitempty"`    // 決まった休みの曜日（"月"〜"日"、祝日は "祝"）
+	Leaves     []string `json:"leaves,omitempty"`      // LeaveNames の値
+}
+
+// 職種・資格の分類
+type Classification struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// 求人の応募資格1つ分
+type License struct {
+	Classification
+	Required bool `json:"required"` // 必須（false なら「歓迎」「優遇」）
+}
+
+// 職種の正規の値（この順に並べる）
+var OccupationTypes = []Classification{
+	{"nurse", "看護師"},
+	{"assistant_nurse", "准看護師"},
+	{"midwife", "助産師"},
+	{"public_health_nurse", "保健師"},
+	{"nursing_assistant", "看護助手"},
+	{"pharmacist", "薬剤師"},
+	{"registered_seller", "登録販売者"},
+	{"pharmacy_clerk", "調剤事務"},
+	{"care_worker", "介護職"},
+	{"care_manager", "ケアマネジャー"},
+	{"social_worker", "相談員"},
+	{"physical_therapist", "理学療法士"},
+	{"occupational_therapist", "作業療法士"},
+	{"speech_therapist", "言語聴覚士"},
+	{"orthoptist", "視能訓練士"},
+	{"radiologic_technologist", "診療放射線技師"},
+	{"clinical_laboratory_technician", "臨床検査技師"},
+	{"clinical_engineer", "臨床工学技士"},
+	{"dietitian", "栄養士"},
+	{"physician", "医師"},
+	{"dentist", "歯科医師"},
+	{"dental_hygienist", "歯科衛生士"},
+	{"dental_technician", "歯科技工士"},
+	{"dental_assistant", "歯科助手"},
+	{"childcare_worker", "保育士"},
+	{"judo_therapist", "柔道整復師"},
+	{"acupuncturist", "鍼灸師"},
+	{"medical_clerk", "医療事務"},
+}
+
+// 資格の正規の値（この順に並べる）
+var LicenseTypes = []Classification{
+	{"nurse", "看護師"},
+	{"assistant_nurse", "准看護師"},
+	{"midwife", "助産師"},
+	{"public_health_nurse", "保健師"},
+	{"certified_nurse", "認定看護師"},
+	{"certified_nurse_specialist", "専門看護師"},
+	{"pharmacist", "薬剤師"},
+	{"registered_seller", "登録販売者"},
+	{"certified_care_worker", "介護福祉士"},
+	{"care_worker_practitioner_training", "介護福祉士実務者研修"},
+	{"care_worker_initial_training", "介護職員初任者研修"},
+	{"care_manager", "介護支援専門員"},
+	{"social_worker", "社会福祉士"},
+	{"psychiatric_social_worker", "精神保健福祉士"},
+	{"physical_therapist", "理学療法士"},
+	{"occupational_therapist", "作業療法士"},
+	{"speech_therapist", "言語聴覚士"},
+	{"orthoptist", "視能訓練士"},
+	{"radiologic_technologist", "診療放射線技師"},
+	{"clinical_laboratory_technician", "臨床検査技師"},
+	{"clinical_engineer", "臨床工学技士"},
+	{"registered_dietitian", "管理栄養士"},
+	{"dietitian", "栄養士"},
+	{"physician", "医師"},
+	{"dentist", "歯科医師"},
+	{"dental_hygienist", "歯科衛生士"},
+	{"dental_technician", "歯科技工士"},
+	{"childcare_worker", "保育士"},
+	{"judo_therapist", "柔道整復師"},
+	{"acupuncturist", "はり師・きゅう師"},
+	{"massage_therapist", "あん摩マッサージ指圧師"},
+	{"drivers_license", "普通自動車運転免許"},
+}
+
+// 駅1つ分のアクセス
+type Transit struct {
+	Station  string `json:"station"`            // 駅名（「駅」は付けない）
+	Line     string `json:"line,omitempty"`     // 路線名（"都営大江戸線"）
+	Operator string `json:"operator,omitempty"` // 事業者（"東京メトロ"、"JR" など。路線名から分かる場合のみ）
+	Mode     string `json:"mode,omitempty"`     // "徒歩"、"バス"、"車"、"自転車"（空なら所要時間は不明）
+	Minutes  int    `json:"minutes"`            // 所要時間（分）
+}
+
+// フィールドのJSON名（出力順）
+var FieldNames = []string{
+	"name",
+	"price",
+	"area",
+	"access",
+	"address",
+	"city",
+	"prefecture",
+	"ward",
+	"street",
+	"postal_code",
+	"prefecture_code",
+	"city_code",
+	"contract",
+	"dept",
+	"detail",
+	"facility_name",
+	"facility_type",
+	"holiday",
+	"license",
+	"occupation",
+	"position",
+	"required_skill",
+	"staff_comment",
+	"station",
+	"nearest_station",
+	"welfare_program",
+	"working_hours",
+	"working_style",
+	"title_original",
+	"date_posted",
+	"valid_through",
+	"identifier",
+	"direct_apply",
+}
+
+// JobDataのフィールドをJSON名で参照するための対応表
+func (data *JobData) Fields() map[string]*string {
+	return map[string]*string{
+		"name":            &data.Name,
+		"price":           &data.Price,
+		"area":            &data.Area,
+		"access":          &data.Access,
+		"address":         &data.Address,
+		"city":            &data.City,
+		"prefecture":      &data.Prefecture,
+		"ward":            &data.Ward,
+		"street":          &data.Street,
+		"postal_code":     &data.PostalCode,
+		"prefecture_code": &data.PrefectureCode,
+		"city_code":       &data.CityCode,
+		"contract":        &data.Contract,
+		"dept":            &data.Dept,
+		"detail":          &data.Detail,
+		"facility_name":   &data.FacilityName,
+		"facility_type":   &data.FacilityType,
+		"holiday":         &data.Holiday,
+		"license":         &data.License,
+		"occupation":      &data.Occupation,
+		"position":        &data.Position,
+		"required_skill":  &data.RequiredSkill,
+		"staff_comment":   &data.StaffComment,
+		"station":         &data.Station,
+		"nearest_station": &data.NearestStation,
+		"welfare_program": &data.WelfareProgram,
+		"working_hours":   &data.WorkingHours,
+		"working_style":   &data.WorkingStyle,
+		"title_original":  &data.TitleOriginal,
+		"date_posted":     &data.DatePosted,
+		"valid_through":   &data.ValidThrough,
+		"identifier":      &data.Identifier,
+		"direct_apply":    &data.DirectApply,
+	}
+}
+
+// 正規化で作る構造化データのフィールドのJSON名（出力順）
+var StructuredFieldNames = []string{
+	"transit",
+	"employment_types",
+	"schedule",
+	"holidays",
+	"welfare_tags",
+	"occupations",
+	"licenses",
+	"experience_years",
+	"departments",
+}
+
+// 構造化データのフィールドをJSON名で参照し、JSONにした値を返す（値がなければ空文字列）
+func (data *JobData) StructuredFields() map[string]string {
+	values := map[string]any{
+		"transit":          data.Transit,
+		"employment_types": data.EmploymentTypes,
+		"schedule":         data.Schedule,
+		"holidays":         data.Holidays,
+		"welfare_tags":     data.WelfareTags,
+		"occupations":      data.Occupations,
+		"licenses":         data.Licenses,
+		"experience_years": data.ExperienceYears,
+		"departments":      data.Departments,
+	}
+	fields := map[string]string{}
+	for name, value := range values {
+		fields[name] = ""
+		if reflect.ValueOf(value).IsNil() {
+			continue
+		}
+		if b, err := json.Marshal(value); err == nil {
+			fields[name] = string(b)
+		}
+	}
+	return fields
+}
+
+// 既知のフィールド名かどうか
+func IsField(name string) bool {
+	for _, field := range FieldNames {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// 構造化データのフィールド名かどうか
+func IsStructuredField(name string) bool {
+	for _, field := range StructuredFieldNames {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
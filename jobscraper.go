@@ -0,0 +1,296 @@
+// Package jobscraper は求人ページから求人データを抽出するライブラリ。
+//
+// 長時間動くサービスに組み込めるよう、エラーはすべて戻り値で返し、
+// 取得・サイト設定・正規化はそれぞれ差し替えられる。
+//
+//	client := jobscraper.New()
+//	jobs, report, err := client.ExtractURL(ctx, "https://example.com/job/123", nil)
+package jobscraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/goodsun/jobscraper/config"
+	"github.com/goodsun/jobscraper/extract"
+	"github.com/goodsun/jobscraper/fetch"
+	"github.com/goodsun/jobscraper/job"
+	"github.com/goodsun/jobscraper/normalize"
+)
+
+// 抽出した求人1件
+type Job = job.JobData
+
+// ページのHTMLを取得する（fetch.HTTPFetcher、fetch.BrowserFetcher など）
+type Fetcher = fetch.Fetcher
+
+// サイト設定の取得元（config.SearchPath など）
+type ConfigStore = config.Store
+
+// 抽出後の値を整える（normalize.Default() など）
+type Normalizer = normalize.Normalizer
+
+// Extractor は求人ページから求人データを抽出する
+type Extractor interface {
+	// リクエストのページを取得して抽出する
+	Extract(ctx context.Context, req *http.Request, opts *Options) ([]Job, *Report, error)
+	// 取得済みのHTMLから抽出する（sourceURL はサイト判定に使う。空でもよい）
+	ExtractHTML(ctx context.Context, html string, sourceURL string, opts *Options) ([]Job, *Report, error)
+}
+
+// 1回の抽出ごとの指定（nil ならすべて既定値）
+type Options struct {
+	Site         string // 使用するサイト設定名（空ならURLから自動検出）
+	Render       bool   // Client.Renderer でレンダリングしたDOMから抽出する
+	ArtifactsDir string // 取得HTML・DOM・ヘッダー・リクエストログ・結果を保存するディレクトリ
+	Explain      bool   // フィールドごとの抽出の経過を Report.Trace に記録する
+}
+
+// 抽出の経過
+type Report struct {
+	URL            string               `json:"url,omitempty"`
+	Site           string               `json:"site"`                    // 使用したサイト設定名
+	ArtifactsDir   string               `json:"artifacts_dir,omitempty"` // アーティファクトを保存したディレクトリ
+	Warnings       []string             `json:"warnings,omitempty"`
+	UnmappedLabels []extract.LabelValue `json:"unmapped_labels,omitempty"` // ラベル辞書に未登録のラベル
+	Trace          *extract.Trace       `json:"trace,omitempty"`           // Options.Explain 時のみ
+}
+
+func (r *Report) warnf(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Client は Extractor の標準実装。nil のフィールドは既定の実装を使う
+type Client struct {
+	Fetcher    Fetcher                   // 既定は fetch.HTTPFetcher
+	Renderer   Fetcher                   // Options.Render 時に使う。既定は fetch.BrowserFetcher
+	Configs    ConfigStore               // 既定は config.DefaultSearchPath()
+	Labels     *config.LabelDictionary   // 既定は検索パス上の labels.json（読めなければ辞書なし）
+	Welfare    *config.WelfareDictionary // 既定は検索パス上の welfare.json（読めなければタグなし）。Normalizer が nil の場合のみ使う
+	Normalizer Normalizer                // 既定は normalize.WithWelfareDictionary(Welfare)
+
+	labelsOnce  sync.Once
+	labelsErr   error
+	welfareOnce sync.Once
+	welfareErr  error
+}
+
+var _ Extractor = (*Client)(nil)
+
+func New() *Client {
+	return &Client{}
+}
+
+// URLのページを取得して抽出する
+func (c *Client) ExtractURL(ctx context.Context, url string, opts *Options) ([]Job, *Report, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.Extract(ctx, req, opts)
+}
+
+func (c *Client) Extract(ctx context.Context, req *http.Request, opts *Options) ([]Job, *Report, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	sourceURL := req.URL.String()
+	report := &Report{URL: sourceURL}
+
+	artifacts, err := c.artifacts(opts, sourceURL, report)
+	if err != nil {
+		return nil, report, err
+	}
+	if artifacts != nil {
+		ctx = fetch.WithArtifacts(ctx, artifacts)
+	}
+
+	fetcher := c.Fetcher
+	if opts.Render {
+		fetcher = c.Renderer
+		if fetcher == nil {
+			fetcher = &fetch.BrowserFetcher{}
+		}
+	} else if fetcher == nil {
+		fetcher = &fetch.HTTPFetcher{}
+	}
+
+	htmlContent, err := fetcher.Fetch(ctx, req)
+	if artifacts != nil {
+		// 通信はここで終わるので、失敗時も含めてリクエストログを書き出す
+		artifacts.Close()
+	}
+	if err != nil {
+		return nil, report, fmt.Errorf("fetching %s: %v", sourceURL, err)
+	}
+	if artifacts != nil && !opts.Render {
+		artifacts.WriteDOM(htmlContent)
+	}
+
+	return c.extract(ctx, htmlContent, sourceURL, opts, report, artifacts)
+}
+
+func (c *Client) ExtractHTML(ctx context.Context, html string, sourceURL string, opts *Options) ([]Job, *Report, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	report := &Report{URL: sourceURL}
+
+	artifacts, err := c.artifacts(opts, sourceURL, report)
+	if err != nil {
+		return nil, report, err
+	}
+	if artifacts != nil {
+		artifacts.WriteFile("raw.html", []byte(html))
+		artifacts.WriteDOM(html)
+	}
+
+	return c.extract(ctx, html, sourceURL, opts, report, artifacts)
+}
+
+func (c *Client) extract(ctx context.Context, htmlContent string, sourceURL string, opts *Options, report *Report, artifacts *fetch.ArtifactWriter) ([]Job, *Report, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, report, err
+	}
+
+	site, err := c.site(ctx, opts, sourceURL, report)
+	if err != nil {
+		return nil, report, err
+	}
+
+	// エンコーディング変換
+	if site.Encoding != "" {
+		convertedContent, err := fetch.ConvertEncoding(htmlContent, site.Encoding)
+		if err != nil {
+			report.warnf("failed to convert encoding from %s: %v", site.Encoding, err)
+		} else {
+			htmlContent = convertedContent
+		}
+	}
+
+	var extractReport extract.Report
+	if opts.Explain {
+		extractReport.Trace = extract.NewTrace()
+	}
+	data, err := extract.Extract(htmlContent, site, c.labels(report), &extractReport)
+	if err != nil {
+		return nil, report, fmt.Errorf("extracting data: %v", err)
+	}
+	report.Warnings = append(report.Warnings, extractReport.Warnings...)
+	report.UnmappedLabels = extractReport.UnmappedLabels
+
+	normalizer := c.Normalizer
+	if normalizer == nil {
+		normalizer = normalize.WithWelfareDictionary(c.welfare(report))
+	}
+	if opts.Explain {
+		changes, err := normalize.Explain(normalizer, data)
+		for _, change := range changes {
+			extractReport.Trace.Normalized(change.Field, change.Normalizer, change.Before, change.After)
+		}
+		extractReport.Trace.Finish(data)
+		report.Trace = extractReport.Trace
+		if err != nil {
+			return nil, report, fmt.Errorf("normalizing data: %v", err)
+		}
+	} else if err := normalizer.Normalize(data); err != nil {
+		return nil, report, fmt.Errorf("normalizing data: %v", err)
+	}
+
+	if artifacts != nil {
+		artifacts.WriteJSON("result.json", data)
+		if report.Trace != nil {
+			artifacts.WriteJSON("trace.json", report.Trace)
+		}
+		if err := artifacts.Err(); err != nil {
+			report.warnf("failed to write artifacts: %v", err)
+		}
+	}
+
+	return []Job{*data}, report, nil
+}
+
+// 指定または自動検出したサイト設定（読めなければ汎用抽出）
+func (c *Client) site(ctx context.Context, opts *Options, sourceURL string, report *Report) (*config.SiteConfig, error) {
+	configs := c.Configs
+	if configs == nil {
+		configs = config.DefaultSearchPath()
+	}
+
+	name := opts.Site
+	if name == "" {
+		name = configs.Detect(sourceURL)
+	}
+	if name == "default" {
+		// サイト設定はなくても Go のプラグインがあるサイト
+		if plugin := extract.PluginForURL(sourceURL); plugin != "" {
+			name = plugin
+		}
+	}
+	report.Site = name
+
+	site, err := configs.Load(ctx, name)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		report.warnf("could not load site config for %s, using generic extraction", name)
+		site = &config.SiteConfig{Name: "default"}
+		if extract.HasPlugin(name) {
+			site.Name = name
+		}
+	}
+	return site, nil
+}
+
+func (c *Client) labels(report *Report) *config.LabelDictionary {
+	c.labelsOnce.Do(func() {
+		if c.Labels == nil {
+			search, ok := c.Configs.(config.SearchPath)
+			if !ok {
+				search = config.DefaultSearchPath()
+			}
+			c.Labels, c.labelsErr = search.LabelDictionary()
+			if c.labelsErr != nil {
+				c.Labels = config.EmptyLabelDictionary()
+			}
+		}
+	})
+	if c.labelsErr != nil {
+		report.warnf("could not load label dictionary: %v", c.labelsErr)
+	}
+	return c.Labels
+}
+
+func (c *Client) welfare(report *Report) *config.WelfareDictionary {
+	c.welfareOnce.Do(func() {
+		if c.Welfare == nil {
+			search, ok := c.Configs.(config.SearchPath)
+			if !ok {
+				search = config.DefaultSearchPath()
+			}
+			c.Welfare, c.welfareErr = search.WelfareDictionary()
+			if c.welfareErr != nil {
+				c.Welfare = config.EmptyWelfareDictionary()
+			}
+		}
+	})
+	if c.welfareErr != nil {
+		report.warnf("could not load welfare dictionary: %v", c.welfareErr)
+	}
+	return c.Welfare
+}
+
+func (c *Client) artifacts(opts *Options, sourceURL string, report *Report) (*fetch.ArtifactWriter, error) {
+	if opts.ArtifactsDir == "" {
+		return nil, nil
+	}
+	artifacts, err := fetch.NewArtifactWriter(opts.ArtifactsDir, sourceURL)
+	if err != nil {
+		return nil, fmt.Errorf("creating artifacts directory: %v", err)
+	}
+	report.ArtifactsDir = artifacts.Dir
+	return artifacts, nil
+}
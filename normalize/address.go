@@ -0,0 +1,295 @@
+package normalize
+
+import (
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// Address は日本の住所を分解したもの
+type Address struct {
+	PostalCode     string // 郵便番号（"604-8001"）
+	Prefecture     string
+	PrefectureCode string // 都道府県コード（JIS X 0401、2桁）
+	County         string // 郡（町村のみ）
+	City           string // 市区町村（東京23区は区）
+	Ward           string // 政令指定都市の区
+	CityCode       string // 全国地方公共団体コード（6桁。政令指定都市の区があれば区のコード）
+	Street         string // 市区町村より後ろ（数字は半角、丁目・番地・号は「1-2-3」の形）
+}
+
+// 都道府県（JIS X 0401 のコード順）
+var prefectures = []string{
+	"北海道", "青森県", "岩手県", "宮城県", "秋田県", "山形県", "福島県", "茨城県", "栃木県", "群馬県",
+	"埼玉県", "千葉県", "東京都", "神奈川県", "新潟県", "富山県", "石川県", "福井県", "山梨県", "長野県",
+	"岐阜県", "静岡県", "愛知県", "三重県", "滋賀県", "京都府", "大阪府", "兵庫県", "奈良県", "和歌山県",
+	"鳥取県", "島根県", "岡山県", "広島県", "山口県", "徳島県", "香川県", "愛媛県", "高知県", "福岡県",
+	"佐賀県", "長崎県", "熊本県", "大分県", "宮崎県", "鹿児島県", "沖縄県",
+}
+
+// 市区町村の一覧（コード・都道府県・郡・市区町村・区のタブ区切り）
+//
+//go:embed municipalities.tsv
+var municipalitiesTSV string
+
+type municipality struct {
+	code       string // JIS X 0402（5桁）
+	prefecture string
+	county     string
+	city       string
+	ward       string // 政令指定都市の区のみ
+}
+
+var (
+	municipalitiesOnce sync.Once
+	municipalities     map[string][]municipality // 都道府県 → 市区町村（区を含む）
+)
+
+func loadMunicipalities() map[string][]municipality {
+	municipalitiesOnce.Do(func() {
+		municipalities = map[string][]municipality{}
+		for _, line := range strings.Split(municipalitiesTSV, "\n") {
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			columns := strings.Split(line, "\t")
+			if len(columns) != 5 {
+				continue
+			}
+			m := municipality{code: columns[0], prefecture: columns[1], county: columns[2], city: columns[3], ward: columns[4]}
+			municipalities[m.prefecture] = append(municipalities[m.prefecture], m)
+		}
+	})
+	return municipalities
+}
+
+// 都道府県名（「京都」のように都道府県を省いたものも可）を正式名とコードにする
+func lookupPrefecture(name string) (string, string, bool) {
+	name = strings.TrimSpace(name)
+	for i, prefecture := range prefectures {
+		if name == prefecture || (name != "" && name == prefectureStem(prefecture)) {
+			return prefecture, fmt.Sprintf("%02d", i+1), true
+		}
+	}
+	return "", "", false
+}
+
+// 都道府県名から末尾の「都」「道」「府」「県」を1文字だけ除いたもの（「京都府」→「京都」）
+func prefectureStem(prefecture string) string {
+	for _, suffix := range []string{"都", "道", "府", "県"} {
+		if stem, ok := strings.CutSuffix(prefecture, suffix); ok {
+			return stem
+		}
+	}
+	return prefecture
+}
+
+// 検査数字を付けた全国地方公共団体コード
+func localGovernmentCode(code string) string {
+	sum := 0
+	for i, c := range code {
+		sum += int(c-'0') * (6 - i)
+	}
+	return fmt.Sprintf("%s%d", code, (11-sum%11)%10)
+}
+
+var (
+	// 先頭の郵便番号（〒は省略可）と、途中の〒付きの郵便番号
+	leadingPostalCodeRegex = regexp.MustCompile(`^〒?\s*(\d{3})-?(\d{4})(?:\D|$)`)
+	postalCodeRegex        = regexp.MustCompile(`〒\s*(\d{3})-?(\d{4})`)
+
+	// 一覧にない市区町村（合併直後など）は形だけで分ける
+	looseCountyRegex = regexp.MustCompile(`^(\S{1,6}?郡)\s*`)
+	looseCityRegex   = regexp.MustCompile(`^(\S{1,6}?[市区町村])\s*`)
+	looseWardRegex   = regexp.MustCompile(`^(\S{1,5}?区)\s*`)
+)
+
+// ParseAddress は住所を郵便番号・都道府県・郡・市区町村・区・それより後ろに分ける。
+// 都道府県が書かれていなくても、市区町村名がどの都道府県でも1つに決まれば補う
+func ParseAddress(text string) Address {
+	var address Address
+	text = foldAddress(text)
+
+	// 郵便番号
+	if m := leadingPostalCodeRegex.FindStringSubmatchIndex(text); m != nil {
+		address.PostalCode = text[m[2]:m[3]] + "-" + text[m[4]:m[5]]
+		text = text[m[5]:]
+	} else if m := postalCodeRegex.FindStringSubmatchIndex(text); m != nil {
+		address.PostalCode = text[m[2]:m[3]] + "-" + text[m[4]:m[5]]
+		text = text[:m[0]] + " " + text[m[1]:]
+	}
+	rest := strings.TrimSpace(text)
+
+	// 都道府県
+	for i, prefecture := range prefectures {
+		if n, ok := matchPrefix(rest, prefecture); ok {
+			address.Prefecture, address.PrefectureCode = prefecture, fmt.Sprintf("%02d", i+1)
+			rest = rest[n:]
+			break
+		}
+	}
+
+	// 市区町村と政令指定都市の区
+	if found, n := matchMunicipality(address.Prefecture, rest); found != nil {
+		rest = rest[n:]
+		address.Prefecture, address.PrefectureCode, _ = lookupPrefecture(found.prefecture)
+		address.County, address.City, address.CityCode = found.county, found.city, localGovernmentCode(found.code)
+		if ward, n := matchWard(found, rest); ward != nil {
+			rest = rest[n:]
+			address.Ward, address.CityCode = ward.ward, localGovernmentCode(ward.code)
+		}
+	} else if address.Prefecture != "" {
+		if m := looseCountyRegex.FindStringSubmatch(rest); m != nil {
+			address.County = m[1]
+			rest = rest[len(m[0]):]
+		}
+		if m := looseCityRegex.FindStringSubmatch(rest); m != nil {
+			address.City = m[1]
+			rest = rest[len(m[0]):]
+			if strings.HasSuffix(address.City, "市") {
+				if m := looseWardRegex.FindStringSubmatch(rest); m != nil {
+					address.Ward = m[1]
+					rest = rest[len(m[0]):]
+				}
+			}
+		}
+	}
+
+	address.Street = normalizeStreet(strings.TrimSpace(rest))
+	return address
+}
+
+// 全角英数字・記号を半角にし、空白をまとめる
+func foldAddress(text string) string {
+	return strings.Join(strings.Fields(width.Fold.String(text)), " ")
+}
+
+// text が prefix で始まっていれば、prefix に当たる長さを返す（間の空白は無視する）
+func matchPrefix(text string, prefix string) (int, bool) {
+	i := 0
+	for _, want := range prefix {
+		for i < len(text) && text[i] == ' ' {
+			i++
+		}
+		if !strings.HasPrefix(text[i:], string(want)) {
+			return 0, false
+		}
+		i += len(string(want))
+	}
+	for i < len(text) && text[i] == ' ' {
+		i++
+	}
+	return i, true
+}
+
+// 最も長く一致する市区町村（郡付きでも郡なしでもよい）。
+// 都道府県が分からない場合は、一致する市町村が1つに決まるときだけ返す（「北区」のような区名だけでは決めない）
+func matchMunicipality(prefecture string, text string) (*municipality, int) {
+	all := loadMunicipalities()
+	var candidates []municipality
+	if prefecture != "" {
+		candidates = all[prefecture]
+	} else {
+		for _, name := range prefectures {
+			candidates = append(candidates, all[name]...)
+		}
+	}
+
+	var best []*municipality
+	bestLength := 0
+	for i := range candidates {
+		m := &candidates[i]
+		if m.ward != "" || (prefecture == "" && strings.HasSuffix(m.city, "区")) {
+			continue
+		}
+		n, ok := matchPrefix(text, m.county+m.city)
+		if !ok || m.county == "" {
+			if n, ok = matchPrefix(text, m.city); !ok {
+				continue
+			}
+		}
+		switch {
+		case n > bestLength:
+			best, bestLength = []*municipality{m}, n
+		case n == bestLength:
+			best = append(best, m)
+		}
+	}
+	if len(best) != 1 {
+		return nil, 0
+	}
+	return best[0], bestLength
+}
+
+// 政令指定都市の区
+func matchWard(city *municipality, text string) (*municipality, int) {
+	for _, m := range loadMunicipalities()[city.prefecture] {
+		if m.ward == "" || m.city != city.city {
+			continue
+		}
+		if n, ok := matchPrefix(text, m.ward); ok {
+			return &m, n
+		}
+	}
+	return nil, 0
+}
+
+var (
+	kanjiChomeRegex   = regexp.MustCompile(`([〇一二三四五六七八九十]+)丁目`)
+	chomeRegex        = regexp.MustCompile(`(\d+)丁目\s*(\d)`)
+	banchiGoRegex     = regexp.MustCompile(`(\d+)\s*番地?\s*(\d+)\s*号?`)
+	banchiRegex       = regexp.MustCompile(`(\d+)\s*番地`)
+	goRegex           = regexp.MustCompile(`-(\d+)\s*号`)
+	noRegex           = regexp.MustCompile(`(\d+)の(\d+)`)
+	kanjiDigitsValues = map[rune]int{'〇': 0, '一': 1, '二': 2, '三': 3, '四': 4, '五': 5, '六': 6, '七': 7, '八': 8, '九': 9}
+)
+
+// 丁目・番地・号の書き方をそろえる（「三丁目2番地5号」「3丁目2-5」「3-2-5」→「3-2-5」）
+func normalizeStreet(street string) string {
+	street = unifyDashes(street)
+	street = kanjiChomeRegex.ReplaceAllStringFunc(street, func(s string) string {
+		return fmt.Sprintf("%d丁目", kanjiNumber(strings.TrimSuffix(s, "丁目")))
+	})
+	street = chomeRegex.ReplaceAllString(street, "$1-$2")
+	street = banchiGoRegex.ReplaceAllString(street, "$1-$2")
+	street = banchiRegex.ReplaceAllString(street, "$1")
+	street = goRegex.ReplaceAllString(street, "-$1")
+	street = noRegex.ReplaceAllString(street, "$1-$2")
+	return strings.ReplaceAll(street, "--", "-")
+}
+
+// 数字に挟まれたハイフンに似た文字（‐ − ― ー など）を "-" にする
+func unifyDashes(text string) string {
+	runes := []rune(text)
+	for i := 1; i+1 < len(runes); i++ {
+		switch runes[i] {
+		case '‐', '‑', '‒', '–', '—', '―', '−', 'ー', '─':
+			if unicode.IsDigit(runes[i-1]) && unicode.IsDigit(runes[i+1]) {
+				runes[i] = '-'
+			}
+		}
+	}
+	return string(runes)
+}
+
+// 漢数字（「二十三」「十」「一〇」など）を数にする
+func kanjiNumber(text string) int {
+	total, digits := 0, 0
+	for _, r := range text {
+		if r == '十' {
+			if digits == 0 {
+				digits = 1
+			}
+			total += digits * 10
+			digits = 0
+			continue
+		}
+		digits = digits*10 + kanjiDigitsValues[r]
+	}
+	return total + digits
+}
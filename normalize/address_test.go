@@ -0,0 +1,88 @@
+package normalize
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/goodsun/jobscraper/job"
+)
+
+func TestLookupPrefecture(t *testing.T) {
+	tests := []struct {
+		name       string
+		prefecture string
+		code       string
+		ok         bool
+	}{
+		{"京都", "京都府", "26", true},
+		{"京都府", "京都府", "26", true},
+		{"東京", "東京都", "13", true},
+		{"東京都", "東京都", "13", true},
+		{"北海道", "北海道", "01", true},
+		{"大阪", "大阪府", "27", true},
+		{"神奈川", "神奈川県", "14", true},
+		{" 大阪府 ", "大阪府", "27", true},
+		{"京", "", "", false},
+		{"", "", "", false},
+	}
+	for _, tt := range tests {
+		prefecture, code, ok := lookupPrefecture(tt.name)
+		if prefecture != tt.prefecture || code != tt.code || ok != tt.ok {
+			t.Errorf("lookupPrefecture(%q) = %q, %q, %v; want %q, %q, %v", tt.name, prefecture, code, ok, tt.prefecture, tt.code, tt.ok)
+		}
+	}
+}
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		text string
+		want Address
+	}{
+		{
+			"〒604-8001 京都府京都市中京区中之町１２３",
+			Address{PostalCode: "604-8001", Prefecture: "京都府", PrefectureCode: "26", City: "京都市", Ward: "中京区", CityCode: "261041", Street: "中之町123"},
+		},
+		{
+			"東京都中野区中央二丁目3番4号",
+			Address{Prefecture: "東京都", PrefectureCode: "13", City: "中野区", CityCode: "131148", Street: "中央2-3-4"},
+		},
+		{
+			"札幌市北区北8条西5丁目",
+			Address{Prefecture: "北海道", PrefectureCode: "01", City: "札幌市", Ward: "北区", CityCode: "011029", Street: "北8条西5丁目"},
+		},
+		{
+			"大阪府大阪市北区梅田1-1-1",
+			Address{Prefecture: "大阪府", PrefectureCode: "27", City: "大阪市", Ward: "北区", CityCode: "271276", Street: "梅田1-1-1"},
+		},
+	}
+	for _, tt := range tests {
+		if got := ParseAddress(tt.text); got != tt.want {
+			t.Errorf("ParseAddress(%q) = %+v; want %+v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestLocation(t *testing.T) {
+	tests := []struct {
+		data job.JobData
+		want job.JobData
+	}{
+		{
+			// エリアから取り出しても Address は書き換えない
+			job.JobData{Area: "東京都中野区中央2-3-4"},
+			job.JobData{Area: "東京都中野区中央2-3-4", Prefecture: "東京都", PrefectureCode: "13", City: "中野区", CityCode: "131148", Street: "中央2-3-4"},
+		},
+		{
+			// 住所に都道府県がなければ別に取れた都道府県を補う
+			job.JobData{Address: "〒735-8686 府中町大通3-5-1", Prefecture: "広島"},
+			job.JobData{Address: "〒735-8686 府中町大通3-5-1", PostalCode: "735-8686", Prefecture: "広島県", PrefectureCode: "34", City: "府中町", CityCode: "343021", Street: "大通3-5-1", Area: "広島県府中町"},
+		},
+	}
+	for _, tt := range tests {
+		data := tt.data
+		Location(&data)
+		if !reflect.DeepEqual(data, tt.want) {
+			t.Errorf("Location(%+v) = %+v; want %+v", tt.data, data, tt.want)
+		}
+	}
+}
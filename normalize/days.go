@@ -0,0 +1,87 @@
+package normalize
+
+import (
+	"regexp"
+	"strings"
+)
+
+// 曜日（月〜日の順）
+var weekdays = []string{"月", "火", "水", "木", "金", "土", "日"}
+
+// 曜日と祝日の並び（「月~金」「月・水・金」「月曜~土曜」「土日祝」「日曜、祝日」）
+var dayRunRegex = regexp.MustCompile(`(?:祝日?|[月火水木金土日](?:曜日?)?)(?:\s*(?:[~〜・、,-]|から)?\s*(?:祝日?|[月火水木金土日](?:曜日?)?))*`)
+
+var dayRunReplacer = strings.NewReplacer("曜日", "", "曜", "", "祝日", "祝", "から", "~")
+
+// 曜日の並び1つ分
+type dayRun struct {
+	days       []string // 範囲を展開した曜日（祝日は "祝"）
+	start, end int
+}
+
+// 文章の中の曜日の並び。「日勤」「月給」「週5日」のような1文字だけのもの（「曜」付きを除く）は数えない
+func findDayRuns(text string) []dayRun {
+	var runs []dayRun
+	for _, m := range dayRunRegex.FindAllStringIndex(text, -1) {
+		matched := text[m[0]:m[1]]
+		tokens := []rune(dayRunReplacer.Replace(matched))
+		if countDayTokens(tokens) < 2 && !strings.Contains(matched, "曜") {
+			continue
+		}
+		if m[0] > 0 && strings.ContainsAny(text[m[0]-1:m[0]], "0123456789") {
+			continue
+		}
+
+		run := dayRun{start: m[0], end: m[1]}
+		previous := -1
+		ranged := false
+		for _, r := range tokens {
+			day := string(r)
+			switch {
+			case day == "祝":
+				run.days = appendDay(run.days, day)
+				previous, ranged = -1, false
+			case weekdayIndex(day) >= 0:
+				if ranged && previous >= 0 {
+					for i := previous; i != weekdayIndex(day); i = (i + 1) % 7 {
+						run.days = appendDay(run.days, weekdays[i])
+					}
+				}
+				run.days = appendDay(run.days, day)
+				previous, ranged = weekdayIndex(day), false
+			case strings.ContainsRune("~〜-", r):
+				ranged = true
+			}
+		}
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+func appendDay(days []string, day string) []string {
+	for _, d := range days {
+		if d == day {
+			return days
+		}
+	}
+	return append(days, day)
+}
+
+func weekdayIndex(day string) int {
+	for i, d := range weekdays {
+		if d == day {
+			return i
+		}
+	}
+	return -1
+}
+
+func countDayTokens(tokens []rune) int {
+	n := 0
+	for _, r := range tokens {
+		if r == '祝' || weekdayIndex(string(r)) >= 0 {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,179 @@
+package normalize
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/goodsun/jobscraper/job"
+)
+
+var (
+	// 診療科目の区切り（「・」は「糖尿病・代謝内科」のように科目名の中にも出てくるので別に扱う）
+	departmentSeparatorRegex = regexp.MustCompile(`[、,/|;\s]+|及び|および`)
+	// 括弧書き（区切る前に除く）
+	departmentParenRegex = regexp.MustCompile(`\([^)]*\)`)
+	// 科目名の鉤括弧・後ろの「など」
+	departmentNoteRegex = regexp.MustCompile(`[「」]|(?:など|等|他|ほか)$`)
+	// 科目がないことを表す書き方（「なし」「-」など。科目にしない）
+	departmentPlaceholderRegex = regexp.MustCompile(`^(?:[-−‐―ー~〜*]+|なし|無し|特になし|特に無し|該当なし|未定|不明)$`)
+)
+
+// 診療科目の書き方の違い → job.DepartmentNames の値
+var departmentVariants = map[string]string{
+	"一般内科":      "内科",
+	"総合内科":      "総合診療科",
+	"総合診療":      "総合診療科",
+	"呼吸器科":      "呼吸器内科",
+	"循環器科":      "循環器内科",
+	"循環器":       "循環器内科",
+	"消化器科":      "消化器内科",
+	"消化器":       "消化器内科",
+	"胃腸科":       "消化器内科",
+	"胃腸内科":      "消化器内科",
+	"腎臓科":       "腎臓内科",
+	"腎臓内科":      "腎臓内科",
+	"糖尿病内科":     "糖尿病・内分泌内科",
+	"糖尿病科":      "糖尿病・内分泌内科",
+	"内分泌内科":     "糖尿病・内分泌内科",
+	"内分泌科":      "糖尿病・内分泌内科",
+	"代謝内科":      "糖尿病・内分泌内科",
+	"糖尿病・代謝内科":  "糖尿病・内分泌内科",
+	"糖尿病代謝内科":   "糖尿病・内分泌内科",
+	"内分泌・代謝内科":  "糖尿病・内分泌内科",
+	"血液科":       "血液内科",
+	"神経内科":      "脳神経内科",
+	"感染症科":      "感染症内科",
+	"腫瘍科":       "腫瘍内科",
+	"リウマチ内科":    "リウマチ科",
+	"膠原病内科":     "リウマチ科",
+	"精神神経科":     "精神科",
+	"神経科":       "精神科",
+	"心臓外科":      "心臓血管外科",
+	"血管外科":      "心臓血管外科",
+	"胃腸外科":      "消化器外科",
+	"脳外科":       "脳神経外科",
+	"整形":        "整形外科",
+	"皮フ科":       "皮膚科",
+	"ひふ科":       "皮膚科",
+	"耳鼻科":       "耳鼻咽喉科",
+	"リハビリ科":     "リハビリテーション科",
+	"リハビリ":      "リハビリテーション科",
+	"リハビリテーション": "リハビリテーション科",
+	"放射線診断科":    "放射線科",
+	"放射線治療科":    "放射線科",
+	"病理科":       "病理診断科",
+	"救急":        "救急科",
+	"救命救急":      "救急科",
+	"救急外来":      "救急科",
+	"透析":        "人工透析",
+	"血液透析":      "人工透析",
+	"透析内科":      "人工透析",
+	"透析科":       "人工透析",
+	"人工透析内科":    "人工透析",
+	"健診":        "健診・人間ドック",
+	"検診":        "健診・人間ドック",
+	"人間ドック":     "健診・人間ドック",
+	"健診センター":    "健診・人間ドック",
+	"緩和ケア科":     "緩和ケア",
+	"緩和ケア内科":    "緩和ケア",
+	"在宅診療":      "訪問診療",
+	"在宅医療":      "訪問診療",
+	"口腔外科":      "歯科口腔外科",
+	"歯科口腔外科":    "歯科口腔外科",
+	"歯科矯正":      "矯正歯科",
+}
+
+// 「・」を含む科目名（「内科・外科・糖尿病・代謝内科」のように「・」で並べた中にあっても分けないよう、区切る前に文章全体から探す）
+var multiPartDepartmentRegex = regexp.MustCompile(multiPartDepartmentPattern())
+
+// job.DepartmentNames と書き方の違いのうち「・」を含むもの（長いものを先に）
+func multiPartDepartmentPattern() string {
+	var names []string
+	for _, name := range job.DepartmentNames {
+		if strings.Contains(name, "・") {
+			names = append(names, name)
+		}
+	}
+	for name := range departmentVariants {
+		if strings.Contains(name, "・") {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) > len(names[j])
+		}
+		return names[i] < names[j]
+	})
+	for i, name := range names {
+		names[i] = regexp.QuoteMeta(name)
+	}
+	return strings.Join(names, "|")
+}
+
+// Departments は Dept の診療科目を分けて job.DepartmentNames の値にする
+func Departments(data *job.JobData) {
+	if departments := ParseDepartments(data.Dept); len(departments) > 0 {
+		data.Departments = departments
+	}
+}
+
+// ParseDepartments は診療科目の文章（「内科、消化器科／循環器内科」）を区切り、書き方の違いを job.DepartmentNames の値にそろえる。
+// 一覧の順に並べ、一覧にない科目は元の言葉のまま出てきた順に後ろに付ける
+func ParseDepartments(text string) []string {
+	found := map[string]bool{}
+	var others []string
+	text = departmentParenRegex.ReplaceAllString(foldAddress(text), " ")
+	text = multiPartDepartmentRegex.ReplaceAllString(text, " $0 ")
+	for _, token := range departmentSeparatorRegex.Split(text, -1) {
+		for _, name := range departmentNames(token) {
+			if found[name] {
+				continue
+			}
+			found[name] = true
+			if !containsDepartment(name) {
+				others = append(others, name)
+			}
+		}
+	}
+
+	var departments []string
+	for _, name := range job.DepartmentNames {
+		if found[name] {
+			departments = append(departments, name)
+		}
+	}
+	return append(departments, others...)
+}
+
+// 区切った1つ分の科目名（「・」でつながった複数の科目なら分ける）
+func departmentNames(token string) []string {
+	token = strings.Trim(departmentNoteRegex.ReplaceAllString(token, ""), "・ ")
+	if token == "" || departmentPlaceholderRegex.MatchString(token) {
+		return nil
+	}
+	if containsDepartment(token) {
+		return []string{token}
+	}
+	if name, ok := departmentVariants[token]; ok {
+		return []string{name}
+	}
+	if parts := strings.Split(token, "・"); len(parts) > 1 {
+		var names []string
+		for _, part := range parts {
+			names = append(names, departmentNames(part)...)
+		}
+		return names
+	}
+	return []string{token}
+}
+
+func containsDepartment(name string) bool {
+	for _, n := range job.DepartmentNames {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package normalize
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDepartments(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"内科、消化器科/循環器内科", []string{"内科", "循環器内科", "消化器内科"}},
+		// 「・」を含む科目名は「・」で並べた中にあっても分けない
+		{"内科・外科・整形外科・糖尿病・代謝内科", []string{"内科", "糖尿病・内分泌内科", "外科", "整形外科"}},
+		{"糖尿病・代謝内科", []string{"糖尿病・内分泌内科"}},
+		{"健診・人間ドック、内科", []string{"内科", "健診・人間ドック"}},
+		{"内科・小児科", []string{"内科", "小児科"}},
+		// 括弧書きと「など」は除く
+		{"整形外科（外来のみ）、リハビリ科など", []string{"整形外科", "リハビリテーション科"}},
+		// 一覧にない科目は元の言葉のまま後ろに付ける
+		{"内科・ペインクリニック", []string{"内科", "ペインクリニック"}},
+		{"血液透析・腎臓内科", []string{"腎臓内科", "人工透析"}},
+		// 科目がないことを表す書き方は除く
+		{"なし", nil},
+		{"-", nil},
+		{"内科、特になし", []string{"内科"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := ParseDepartments(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseDepartments(%q) = %q; want %q", tt.text, got, tt.want)
+		}
+	}
+}
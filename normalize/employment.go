@@ -0,0 +1,97 @@
+package normalize
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/goodsun/jobscraper/job"
+)
+
+// 雇用形態の言葉（長いものを先に。「非常勤」は「常勤」より先。schema.org の値は大文字小文字を問わない）
+var employmentWordRegex = regexp.MustCompile(`紹介予定派遣|正社員登用|社員登用|常勤パート|非常勤|正社員|正職員|契約社員|契約職員|嘱託|パート|アルバイト|バイト|派遣|業務委託|委託|フリーランス|単発|日雇い?|スポット|インターン|ボランティア|常勤|\b(?i:FULL_TIME|PART_TIME|CONTRACTOR|CONTRACT|TEMPORARY|INTERN|VOLUNTEER|PER_DIEM|OTHER)\b`)
+
+// 言葉 → 雇用形態（「常勤」は、パート・アルバイト以外の雇用形態が書かれていない場合だけ正社員とする）
+var employmentWords = map[string]string{
+	"紹介予定派遣": "紹介予定派遣",
+	"常勤パート":  "パート・アルバイト",
+	"非常勤":    "パート・アルバイト",
+	"正社員":    "正社員",
+	"正職員":    "正社員",
+	"契約社員":   "契約社員",
+	"契約職員":   "契約社員",
+	"嘱託":     "契約社員",
+	"パート":    "パート・アルバイト",
+	"アルバイト":  "パート・アルバイト",
+	"バイト":    "パート・アルバイト",
+	"派遣":     "派遣",
+	"業務委託":   "業務委託",
+	"委託":     "業務委託",
+	"フリーランス": "業務委託",
+	"単発":     "日雇い・単発",
+	"日雇":     "日雇い・単発",
+	"日雇い":    "日雇い・単発",
+	"スポット":   "日雇い・単発",
+	"インターン":  "インターン",
+	"ボランティア": "ボランティア",
+}
+
+// EmploymentWords は文章に書かれている雇用形態の言葉を、書かれている順にそのまま返す（同じ言葉は1つ）。
+// 「正社員登用」「社員登用」は雇用形態ではないので除く
+func EmploymentWords(text string) []string {
+	var words []string
+	seen := map[string]bool{}
+	for _, word := range employmentWordRegex.FindAllString(foldAddress(text), -1) {
+		if word == "正社員登用" || word == "社員登用" || seen[word] {
+			continue
+		}
+		seen[word] = true
+		words = append(words, word)
+	}
+	return words
+}
+
+// Employment は Contract（なければ WorkingStyle）の雇用形態を正規の値の一覧にする
+func Employment(data *job.JobData) {
+	types := ParseEmploymentTypes(data.Contract)
+	if len(types) == 0 {
+		types = ParseEmploymentTypes(data.WorkingStyle)
+	}
+	if len(types) > 0 {
+		data.EmploymentTypes = types
+	}
+}
+
+// ParseEmploymentTypes は雇用形態の文章（「正社員、パート」「常勤・非常勤」「FULL_TIME」）を
+// job.EmploymentTypeNames の値にして、その順に並べる。「正社員登用あり」は雇用形態として数えない
+func ParseEmploymentTypes(text string) []string {
+	found := map[string]bool{}
+	full := false
+	for _, word := range EmploymentWords(text) {
+		if word == "常勤" {
+			full = true
+			continue
+		}
+		if t, ok := employmentWords[word]; ok {
+			found[t] = true
+		} else if t, ok := job.SchemaOrgEmploymentTypes[strings.ToUpper(word)]; ok {
+			found[t] = true
+		}
+	}
+	if full {
+		others := len(found)
+		if found["パート・アルバイト"] {
+			others--
+		}
+		if others == 0 {
+			found["正社員"] = true
+		}
+	}
+
+	var types []string
+	for _, name := range job.EmploymentTypeNames {
+		if found[name] {
+			types = append(types, name)
+		}
+	}
+	return types
+}
@@ -0,0 +1,67 @@
+package normalize
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/goodsun/jobscraper/job"
+)
+
+func TestParseEmploymentTypes(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"正社員、パート", []string{"正社員", "パート・アルバイト"}},
+		{"常勤・非常勤", []string{"正社員", "パート・アルバイト"}},
+		{"常勤（正職員）", []string{"正社員"}},
+		{"常勤パート", []string{"パート・アルバイト"}},
+		{"契約社員（正社員登用あり）", []string{"契約社員"}},
+		{"派遣・紹介予定派遣", []string{"派遣", "紹介予定派遣"}},
+		{"アルバイト", []string{"パート・アルバイト"}},
+		{"FULL_TIME", []string{"正社員"}},
+		{"PART_TIME", []string{"パート・アルバイト"}},
+		{"CONTRACTOR", []string{"業務委託"}},
+		{"full_time、Contract", []string{"正社員", "契約社員"}},
+		{"OTHER", []string{"その他"}},
+		// 英単語の一部は雇用形態にしない
+		{"Mother's room", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := ParseEmploymentTypes(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseEmploymentTypes(%q) = %q; want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestEmploymentFallsBackToWorkingStyle(t *testing.T) {
+	data := job.JobData{WorkingStyle: "日勤常勤"}
+	Employment(&data)
+	if want := []string{"正社員"}; !reflect.DeepEqual(data.EmploymentTypes, want) {
+		t.Errorf("EmploymentTypes = %q; want %q", data.EmploymentTypes, want)
+	}
+
+	data = job.JobData{Contract: "パート", WorkingStyle: "常勤"}
+	Employment(&data)
+	if want := []string{"パート・アルバイト"}; !reflect.DeepEqual(data.EmploymentTypes, want) {
+		t.Errorf("EmploymentTypes = %q; want %q", data.EmploymentTypes, want)
+	}
+}
+
+func TestEmploymentWords(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"常勤・非常勤（正社員登用あり）", []string{"常勤", "非常勤"}},
+		{"パート、パート", []string{"パート"}},
+		{"FULL_TIME、PART_TIME", []string{"FULL_TIME", "PART_TIME"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := EmploymentWords(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("EmploymentWords(%q) = %q; want %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package normalize
+
+import (
+	"fmt"
+
+	"github.com/goodsun/jobscraper/job"
+)
+
+// Named は Normalizer に名前を付ける（Explain で変化の出どころとして表示する）
+type Named struct {
+	Name string
+	Normalizer
+}
+
+// 正規化で変わったフィールド1つ分
+type Change struct {
+	Normalizer string // Named の名前（名前がなければ型名）
+	Field      string
+	Before     string
+	After      string
+}
+
+// Explain は Normalize と同じ処理をし、変わったフィールドを返す（Chain は要素ごとに記録する）
+func Explain(n Normalizer, data *job.JobData) ([]Change, error) {
+	switch n := n.(type) {
+	case Chain:
+		var changes []Change
+		for _, normalizer := range n {
+			stepChanges, err := Explain(normalizer, data)
+			changes = append(changes, stepChanges...)
+			if err != nil {
+				return changes, err
+			}
+		}
+		return changes, nil
+	case Named:
+		if chain, ok := n.Normalizer.(Chain); ok {
+			return Explain(chain, data)
+		}
+		return diff(n.Name, n.Normalizer, data)
+	}
+	return diff(fmt.Sprintf("%T", n), n, data)
+}
+
+// 変わったフィールドを返す（構造化データのフィールドはJSONにして比べる）
+func diff(name string, n Normalizer, data *job.JobData) ([]Change, error) {
+	before := *data
+	// 構造化データは要素を書き換えられても比べられるよう、正規化の前にJSONにしておく
+	previousStructured := data.StructuredFields()
+	err := n.Normalize(data)
+
+	var changes []Change
+	previous, fields := before.Fields(), data.Fields()
+	for _, field := range job.FieldNames {
+		if *previous[field] != *fields[field] {
+			changes = append(changes, Change{Normalizer: name, Field: field, Before: *previous[field], After: *fields[field]})
+		}
+	}
+	structured := data.StructuredFields()
+	for _, field := range job.StructuredFieldNames {
+		if previousStructured[field] != structured[field] {
+			changes = append(changes, Change{Normalizer: name, Field: field, Before: previousStructured[field], After: structured[field]})
+		}
+	}
+	return changes, err
+}
@@ -0,0 +1,112 @@
+package normalize
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/goodsun/jobscraper/job"
+)
+
+var (
+	// 年間休日（「年間休日120日以上」「年間休日数:110日」）
+	annualHolidaysRegex = regexp.MustCompile(`年間休日(?:数)?[^\d。]{0,6}?(\d{2,3})\s*日`)
+	// 「年間休日」のラベルの値だけが入っている場合（「120日」「110日以上」）
+	daysCountRegex = regexp.MustCompile(`^(?:約)?\s*(\d{2,3})\s*日\s*(?:以上|程度)?$`)
+	// 週休（「完全週休2日制」「週休二日」「4週8休」）
+	fullWeeklyRestRegex = regexp.MustCompile(`完全週休\s*([1-3一二三])\s*日`)
+	weeklyRestRegex     = regexp.MustCompile(`週休\s*([1-3一二三])\s*日`)
+	fourWeeksRestRegex  = regexp.MustCompile(`4\s*週\s*(\d)\s*休`)
+	// 曜日の後ろが出勤の話（「土日出勤あり」）
+	daysWorkRegex = regexp.MustCompile(`^\s*(?:は|も|の)?\s*(?:出勤|勤務|診療|営業)`)
+)
+
+// 休暇の言葉
+var leaveRegexes = []struct {
+	leave string
+	regex *regexp.Regexp
+}{
+	{"有給休暇", regexp.MustCompile(`有給|有休|年次休暇|年休`)},
+	{"夏季休暇", regexp.MustCompile(`夏[季期]|夏休`)},
+	{"冬季休暇", regexp.MustCompile(`冬[季期]|冬休`)},
+	{"年末年始休暇", regexp.MustCompile(`年末年始`)},
+	{"慶弔休暇", regexp.MustCompile(`慶弔`)},
+	{"産前産後休暇", regexp.MustCompile(`産前|産後|産休`)},
+	{"育児休業", regexp.MustCompile(`育児休|育休`)},
+	{"介護休業", regexp.MustCompile(`介護休`)},
+	{"子の看護休暇", regexp.MustCompile(`看護休暇`)},
+	{"リフレッシュ休暇", regexp.MustCompile(`リフレッシュ休`)},
+	{"誕生日休暇", regexp.MustCompile(`誕生日休|バースデー休`)},
+	{"特別休暇", regexp.MustCompile(`特別休暇`)},
+}
+
+var kanjiDigits = strings.NewReplacer("一", "1", "二", "2", "三", "3")
+
+// Holidays は Holiday・WelfareProgram・Detail から年間休日・週休・休みの曜日・休暇の種類を取り出す
+func Holidays(data *job.JobData) {
+	notes := strings.Join([]string{data.Holiday, data.WelfareProgram, data.Detail}, "\n")
+	if strings.TrimSpace(notes) == "" {
+		return
+	}
+	holidays := ParseHolidays(data.Holiday, notes)
+	if holidays.AnnualDays > 0 || holidays.WeeklyRest != "" || len(holidays.DaysOff) > 0 || len(holidays.Leaves) > 0 {
+		data.Holidays = &holidays
+	}
+}
+
+// ParseHolidays は休日の文章（holiday）と、休日・福利厚生・仕事内容などの文章（notes。holiday も含めて渡す）から休日・休暇を取り出す。
+// 休みの曜日は、holiday に書かれた曜日（「出勤」が続くものを除く）と、notes で「休み」が続く曜日
+func ParseHolidays(holiday string, notes string) job.Holidays {
+	var holidays job.Holidays
+	holiday = foldAddress(holiday)
+	notes = foldAddress(notes)
+
+	if m := annualHolidaysRegex.FindStringSubmatch(notes); m != nil {
+		holidays.AnnualDays, _ = strconv.Atoi(m[1])
+	} else if m := daysCountRegex.FindStringSubmatch(holiday); m != nil {
+		// 1年に52日より少なければ年間休日ではない
+		if days, _ := strconv.Atoi(m[1]); days >= 52 {
+			holidays.AnnualDays = days
+		}
+	}
+
+	switch {
+	case fullWeeklyRestRegex.MatchString(notes):
+		holidays.WeeklyRest = "完全週休" + kanjiDigits.Replace(fullWeeklyRestRegex.FindStringSubmatch(notes)[1]) + "日"
+	case weeklyRestRegex.MatchString(notes):
+		holidays.WeeklyRest = "週休" + kanjiDigits.Replace(weeklyRestRegex.FindStringSubmatch(notes)[1]) + "日"
+	case fourWeeksRestRegex.MatchString(notes):
+		holidays.WeeklyRest = "4週" + fourWeeksRestRegex.FindStringSubmatch(notes)[1] + "休"
+	}
+
+	found := map[string]bool{}
+	for _, run := range findDayRuns(holiday) {
+		if !daysWorkRegex.MatchString(holiday[run.end:]) {
+			for _, day := range run.days {
+				found[day] = true
+			}
+		}
+	}
+	for _, run := range findDayRuns(notes) {
+		if daysOffRegex.MatchString(notes[run.end:]) {
+			for _, day := range run.days {
+				found[day] = true
+			}
+		}
+	}
+	for _, day := range weekdays {
+		if found[day] {
+			holidays.DaysOff = append(holidays.DaysOff, day)
+		}
+	}
+	if found["祝"] {
+		holidays.DaysOff = append(holidays.DaysOff, "祝")
+	}
+
+	for _, l := range leaveRegexes {
+		if l.regex.MatchString(notes) {
+			holidays.Leaves = append(holidays.Leaves, l.leave)
+		}
+	}
+	return holidays
+}
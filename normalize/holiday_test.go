@@ -0,0 +1,61 @@
+package normalize
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/goodsun/jobscraper/job"
+)
+
+func TestParseHolidays(t *testing.T) {
+	tests := []struct {
+		holiday string
+		notes   string
+		want    job.Holidays
+	}{
+		{
+			"4週8休（シフト制）", "夏季休暇、年末年始休暇",
+			job.Holidays{WeeklyRest: "4週8休", Leaves: []string{"夏季休暇", "年末年始休暇"}},
+		},
+		{
+			"完全週休2日制（土日祝）年間休日120日", "有給休暇 産休・育休",
+			job.Holidays{
+				AnnualDays: 120,
+				WeeklyRest: "完全週休2日",
+				DaysOff:    []string{"土", "日", "祝"},
+				Leaves:     []string{"有給休暇", "産前産後休暇", "育児休業"},
+			},
+		},
+		{
+			"週休2日制 日曜・祝日", "",
+			job.Holidays{WeeklyRest: "週休2日", DaysOff: []string{"日", "祝"}},
+		},
+		{
+			"年間休日110日", "リフレッシュ休暇あり",
+			job.Holidays{AnnualDays: 110, Leaves: []string{"リフレッシュ休暇"}},
+		},
+		{
+			// 出勤の曜日は休みにしない
+			"土曜出勤あり 日曜休み", "",
+			job.Holidays{DaysOff: []string{"日"}},
+		},
+		{
+			// 年間休日にならない日数は数えない
+			"シフト制（月8～9日）", "",
+			job.Holidays{},
+		},
+	}
+	for _, tt := range tests {
+		if got := ParseHolidays(tt.holiday, tt.holiday+"\n"+tt.notes); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseHolidays(%q, %q) = %+v; want %+v", tt.holiday, tt.notes, got, tt.want)
+		}
+	}
+}
+
+func TestHolidaysFromWelfareProgram(t *testing.T) {
+	data := job.JobData{WelfareProgram: "社会保険完備、育児休業取得実績あり"}
+	Holidays(&data)
+	if data.Holidays == nil || !reflect.DeepEqual(data.Holidays.Leaves, []string{"育児休業"}) {
+		t.Errorf("Holidays = %+v; want leaves [育児休業]", data.Holidays)
+	}
+}
@@ -0,0 +1,45 @@
+// Package normalize は抽出した求人データの値を整える。
+package normalize
+
+import (
+	"strings"
+
+	"github.com/goodsun/jobscraper/job"
+)
+
+// 住所（なければエリア）から郵便番号・都道府県・市区町村・区・番地とそれぞれのコードを取り出す。
+// Address は取得したまま残す（エリアから取り出した場合も書き換えない）
+func Location(data *job.JobData) {
+	text := data.Address
+	if text == "" {
+		text = data.Area
+	}
+	if text == "" {
+		return
+	}
+
+	address := ParseAddress(text)
+	// 住所に都道府県がなく、都道府県だけ別に取れている場合（JSON-LDの addressRegion など）はそれを補って読み直す
+	if address.Prefecture == "" {
+		if prefecture, _, ok := lookupPrefecture(data.Prefecture); ok {
+			address = ParseAddress(prefecture + strings.TrimSpace(text))
+		}
+	}
+
+	if address.PostalCode != "" {
+		data.PostalCode = address.PostalCode
+	}
+	if address.Prefecture != "" {
+		data.Prefecture, data.PrefectureCode = address.Prefecture, address.PrefectureCode
+	} else if prefecture, code, ok := lookupPrefecture(data.Prefecture); ok {
+		data.Prefecture, data.PrefectureCode = prefecture, code
+	}
+	if address.City != "" {
+		data.City, data.Ward, data.CityCode = address.City, address.Ward, address.CityCode
+		data.Street = address.Street
+	}
+
+	if data.Area == "" && data.Prefecture != "" && data.City != "" {
+		data.Area = data.Prefecture + data.City
+	}
+}
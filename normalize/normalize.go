@@ -0,0 +1,50 @@
+package normalize
+
+import (
+	"github.com/goodsun/jobscraper/config"
+	"github.com/goodsun/jobscraper/job"
+)
+
+// Normalizer は抽出直後の求人データを整える
+type Normalizer interface {
+	Normalize(data *job.JobData) error
+}
+
+// Func は関数を Normalizer として使うためのアダプター
+type Func func(data *job.JobData)
+
+func (f Func) Normalize(data *job.JobData) error {
+	f(data)
+	return nil
+}
+
+// Chain は Normalizer を順に適用する（最初のエラーで止まる）
+type Chain []Normalizer
+
+func (c Chain) Normalize(data *job.JobData) error {
+	for _, normalizer := range c {
+		if err := normalizer.Normalize(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 標準の正規化処理（福利厚生のタグは設定の検索パスの welfare.json で付ける）
+func Default() Normalizer {
+	return WithWelfareDictionary(nil)
+}
+
+// 福利厚生のタグ辞書を指定した標準の正規化処理（nil なら設定の検索パスの welfare.json）
+func WithWelfareDictionary(dictionary *config.WelfareDictionary) Normalizer {
+	return Chain{
+		Named{"location", Func(Location)},
+		Named{"transit", Func(Transit)},
+		Named{"employment", Func(Employment)},
+		Named{"schedule", Func(Schedule)},
+		Named{"holidays", Func(Holidays)},
+		Named{"welfare", Welfare(dictionary)},
+		Named{"qualifications", Func(Qualifications)},
+		Named{"departments", Func(Departments)},
+	}
+}
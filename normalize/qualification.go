@@ -0,0 +1,227 @@
+package normalize
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/goodsun/jobscraper/job"
+)
+
+// 資格の言葉（同じ位置から始まる言葉は長いものを先に）
+var licenseWordRegex = regexp.MustCompile(`准看護師|准看|認定看護師|専門看護師|正看護師|看護師|正看|助産師|保健師|薬剤師|登録販売者|介護福祉士実務者研修|介護福祉士|実務者研修|ヘルパー1級|介護職員初任者研修|初任者研修|ホームヘルパー2級|ヘルパー2級|介護支援専門員|ケアマネジャー|ケアマネージャー|ケアマネ|精神保健福祉士|社会福祉士|理学療法士|作業療法士|言語聴覚士|視能訓練士|診療放射線技師|臨床検査技師|臨床工学技士|管理栄養士|栄養士|歯科衛生士|歯科技工士|歯科医師|医師|保育士|柔道整復師|鍼灸師|はり師|きゅう師|あん摩マッサージ指圧師|普通自動車運転免許|普通自動車免許|普通免許|運転免許`)
+
+// 資格の言葉 → job.LicenseTypes のコード
+var licenseWords = map[string]string{
+	"准看護師": "assistant_nurse", "准看": "assistant_nurse",
+	"認定看護師": "certified_nurse", "専門看護師": "certified_nurse_specialist",
+	"正看護師": "nurse", "看護師": "nurse", "正看": "nurse",
+	"助産師": "midwife", "保健師": "public_health_nurse",
+	"薬剤師": "pharmacist", "登録販売者": "registered_seller",
+	"介護福祉士":      "certified_care_worker",
+	"介護福祉士実務者研修": "care_worker_practitioner_training", "実務者研修": "care_worker_practitioner_training", "ヘルパー1級": "care_worker_practitioner_training",
+	"介護職員初任者研修": "care_worker_initial_training", "初任者研修": "care_worker_initial_training", "ホームヘルパー2級": "care_worker_initial_training", "ヘルパー2級": "care_worker_initial_training",
+	"介護支援専門員": "care_manager", "ケアマネジャー": "care_manager", "ケアマネージャー": "care_manager", "ケアマネ": "care_manager",
+	"精神保健福祉士": "psychiatric_social_worker", "社会福祉士": "social_worker",
+	"理学療法士": "physical_therapist", "作業療法士": "occupational_therapist", "言語聴覚士": "speech_therapist", "視能訓練士": "orthoptist",
+	"診療放射線技師": "radiologic_technologist", "臨床検査技師": "clinical_laboratory_technician", "臨床工学技士": "clinical_engineer",
+	"管理栄養士": "registered_dietitian", "栄養士": "dietitian",
+	"歯科衛生士": "dental_hygienist", "歯科技工士": "dental_technician", "歯科医師": "dentist", "医師": "physician",
+	"保育士": "childcare_worker", "柔道整復師": "judo_therapist",
+	"鍼灸師": "acupuncturist", "はり師": "acupuncturist", "きゅう師": "acupuncturist", "あん摩マッサージ指圧師": "massage_therapist",
+	"普通自動車運転免許": "drivers_license", "普通自動車免許": "drivers_license", "普通免許": "drivers_license", "運転免許": "drivers_license",
+}
+
+// 職種の言葉（同じ位置から始まる言葉は長いものを先に）
+var occupationWordRegex = regexp.MustCompile(`看護助手|看護補助|准看護師|准看|認定看護師|専門看護師|正看護師|看護師|正看|ナース|助産師|保健師|薬剤師|登録販売者|調剤事務|介護福祉士|介護職|介護スタッフ|介護士|ヘルパー|介護支援専門員|ケアマネジャー|ケアマネージャー|ケアマネ|生活相談員|支援相談員|相談員|精神保健福祉士|社会福祉士|ソーシャルワーカー|理学療法士|作業療法士|言語聴覚士|視能訓練士|診療放射線技師|放射線技師|臨床検査技師|臨床工学技士|管理栄養士|栄養士|歯科衛生士|歯科技工士|歯科助手|歯科医師|医師|保育士|柔道整復師|鍼灸師|医療事務|医療秘書|医療クラーク`)
+
+// 職種の言葉 → job.OccupationTypes のコード
+var occupationWords = map[string]string{
+	"看護助手": "nursing_assistant", "看護補助": "nursing_assistant",
+	"准看護師": "assistant_nurse", "准看": "assistant_nurse",
+	"認定看護師": "nurse", "専門看護師": "nurse", "正看護師": "nurse", "看護師": "nurse", "正看": "nurse", "ナース": "nurse",
+	"助産師": "midwife", "保健師": "public_health_nurse",
+	"薬剤師": "pharmacist", "登録販売者": "registered_seller", "調剤事務": "pharmacy_clerk",
+	"介護福祉士": "care_worker", "介護職": "care_worker", "介護スタッフ": "care_worker", "介護士": "care_worker", "ヘルパー": "care_worker",
+	"介護支援専門員": "care_manager", "ケアマネジャー": "care_manager", "ケアマネージャー": "care_manager", "ケアマネ": "care_manager",
+	"生活相談員": "social_worker", "支援相談員": "social_worker", "相談員": "social_worker",
+	"精神保健福祉士": "social_worker", "社会福祉士": "social_worker", "ソーシャルワーカー": "social_worker",
+	"理学療法士": "physical_therapist", "作業療法士": "occupational_therapist", "言語聴覚士": "speech_therapist", "視能訓練士": "orthoptist",
+	"診療放射線技師": "radiologic_technologist", "放射線技師": "radiologic_technologist",
+	"臨床検査技師": "clinical_laboratory_technician", "臨床工学技士": "clinical_engineer",
+	"管理栄養士": "dietitian", "栄養士": "dietitian",
+	"歯科衛生士": "dental_hygienist", "歯科技工士": "dental_technician", "歯科助手": "dental_assistant", "歯科医師": "dentist", "医師": "physician",
+	"保育士": "childcare_worker", "柔道整復師": "judo_therapist", "鍼灸師": "acupuncturist",
+	"医療事務": "medical_clerk", "医療秘書": "medical_clerk", "医療クラーク": "medical_clerk",
+}
+
+// 資格 → その資格で働く職種（コードが同じものは書かない）
+var licenseOccupations = map[string]string{
+	"certified_nurse":                   "nurse",
+	"certified_nurse_specialist":        "nurse",
+	"certified_care_worker":             "care_worker",
+	"care_worker_practitioner_training": "care_worker",
+	"care_worker_initial_training":      "care_worker",
+	"psychiatric_social_worker":         "social_worker",
+	"registered_dietitian":              "dietitian",
+	"massage_therapist":                 "acupuncturist",
+	"drivers_license":                   "",
+}
+
+var (
+	// 歓迎の資格・必須の資格の目印（「要相談」は必須にしない）
+	welcomeRegex  = regexp.MustCompile(`歓迎|優遇|尚可|なお可|あれば|望ましい|プラス|あると`)
+	requiredRegex = regexp.MustCompile(`必須|必要|要(?:資格|免許|\))|のみ|限る|お持ちの方`)
+	// 資格のすぐ後の「可」「も可」「でも可」「は要相談」。その資格だけを歓迎とし、前の資格には使わない
+	// （「看護師（ブランク可）」のように別のことが可なものは含まない）
+	allowedRegex = regexp.MustCompile(`^\s*(?:免許|資格)?\s*(?:(?:も|でも)?可|は?(?:要相談|応相談))`)
+	// 資格のすぐ後の「不可」（「准看護師不可」）。その資格は応募資格に入れない
+	deniedRegex = regexp.MustCompile(`^\s*(?:免許|資格)?\s*(?:は|も|では)?不可`)
+	// 資格の並び（「看護師・准看護師（必須）」のように後ろの目印を前の資格にも使う）
+	licenseSeparatorRegex = regexp.MustCompile(`^\s*(?:[・、,/]|または|又は|もしくは|及び|および|or)?\s*(?:免許|資格)?\s*(?:[・、,/]|または|又は|もしくは|及び|および|or)?\s*$`)
+	// 文の区切り
+	clauseSeparatorRegex = regexp.MustCompile(`[。\n■◆●【]`)
+
+	// 経験年数（「臨床経験3年以上」「3年以上の実務経験」）
+	experienceYearsRegex = regexp.MustCompile(`(?:経験|実務|臨床|勤務)[^\d。]{0,12}?([0-9]+|[一二三四五六七八九十]+)\s*年以上|([0-9]+|[一二三四五六七八九十]+)\s*年以上の[^\d。]{0,12}?(?:経験|実務)`)
+	noExperienceRegex    = regexp.MustCompile(`未経験(?:者)?\s*(?:可|OK|歓迎|応募可|の方も)|経験不問|経験問わず`)
+)
+
+// Qualifications は Occupation（なければ必須の資格、それもなければ Name）から職種を、
+// License・RequiredSkill から資格（必須・歓迎）を、RequiredSkill・License・Name から必要な経験年数を取り出す
+func Qualifications(data *job.JobData) {
+	licenses := ParseLicenses(strings.Join([]string{data.License, data.RequiredSkill}, "\n"))
+	if len(licenses) > 0 {
+		data.Licenses = licenses
+	}
+
+	occupations := ParseOccupations(data.Occupation)
+	if len(occupations) == 0 {
+		occupations = licenseOccupationTypes(licenses)
+	}
+	if len(occupations) == 0 {
+		occupations = ParseOccupations(data.Name)
+	}
+	if len(occupations) > 0 {
+		data.Occupations = occupations
+	}
+
+	if years, ok := ParseExperienceYears(strings.Join([]string{data.RequiredSkill, data.License, data.Name}, "\n")); ok {
+		data.ExperienceYears = &years
+	}
+}
+
+// ParseOccupations は職種の文章を job.OccupationTypes の値にして、その順に並べる
+func ParseOccupations(text string) []job.Classification {
+	found := map[string]bool{}
+	for _, word := range occupationWordRegex.FindAllString(foldAddress(text), -1) {
+		found[occupationWords[word]] = true
+	}
+	return classify(job.OccupationTypes, found)
+}
+
+// ParseLicenses は応募資格の文章（「看護師免許必須、保健師あれば尚可」）を job.LicenseTypes の値にして、その順に並べる。
+// 資格の後ろ（次の資格まで）に「歓迎」「尚可」などがあれば歓迎の資格とし、「・」「、」だけで次の資格に続く場合は次の資格の目印に合わせる。
+// 資格のすぐ後の「可」「も可」「要相談」はその資格だけを歓迎とし（「正看護師、准看護師可」の正看護師は必須のまま）、
+// 「不可」の資格は入れない。同じ資格が必須と歓迎の両方に出てきたら必須とする
+func ParseLicenses(text string) []job.License {
+	required := map[string]bool{}
+	found := map[string]bool{}
+	for _, clause := range clauseSeparatorRegex.Split(foldAddress(text), -1) {
+		matches := licenseWordRegex.FindAllStringIndex(clause, -1)
+		// 資格ごとの目印（1：必須、-1：歓迎、-2：その資格だけ歓迎、-3：不可、0：なし）
+		marks := make([]int, len(matches))
+		for i, m := range matches {
+			next := len(clause)
+			if i+1 < len(matches) {
+				next = matches[i+1][0]
+			}
+			following := clause[m[1]:next]
+			switch {
+			case deniedRegex.MatchString(following):
+				marks[i] = -3
+			case welcomeRegex.MatchString(following):
+				marks[i] = -1
+			case requiredRegex.MatchString(following):
+				marks[i] = 1
+			case allowedRegex.MatchString(following):
+				marks[i] = -2
+			case i+1 < len(matches) && licenseSeparatorRegex.MatchString(following):
+				marks[i] = 0
+			default:
+				marks[i] = 1
+			}
+		}
+		for i := len(marks) - 1; i >= 0; i-- {
+			if marks[i] == 0 {
+				marks[i] = marks[i+1]
+				if marks[i] < -1 {
+					marks[i] = 1
+				}
+			}
+		}
+		for i, m := range matches {
+			if marks[i] == -3 {
+				continue
+			}
+			code := licenseWords[clause[m[0]:m[1]]]
+			found[code] = true
+			required[code] = required[code] || marks[i] > 0
+		}
+	}
+
+	var licenses []job.License
+	for _, classification := range classify(job.LicenseTypes, found) {
+		licenses = append(licenses, job.License{Classification: classification, Required: required[classification.Code]})
+	}
+	return licenses
+}
+
+// ParseExperienceYears は必要な経験年数を返す（「未経験可」「経験不問」なら0）
+func ParseExperienceYears(text string) (int, bool) {
+	text = foldAddress(text)
+	if noExperienceRegex.MatchString(text) {
+		return 0, true
+	}
+	m := experienceYearsRegex.FindStringSubmatch(text)
+	if m == nil {
+		return 0, false
+	}
+	years := m[1]
+	if years == "" {
+		years = m[2]
+	}
+	if n, err := strconv.Atoi(years); err == nil {
+		return n, true
+	}
+	return kanjiNumber(years), true
+}
+
+// 必須の資格から分かる職種
+func licenseOccupationTypes(licenses []job.License) []job.Classification {
+	found := map[string]bool{}
+	for _, license := range licenses {
+		if !license.Required {
+			continue
+		}
+		code, ok := licenseOccupations[license.Code]
+		if !ok {
+			code = license.Code
+		}
+		if code != "" {
+			found[code] = true
+		}
+	}
+	return classify(job.OccupationTypes, found)
+}
+
+// 見つかったコードを分類の順に並べる
+func classify(types []job.Classification, found map[string]bool) []job.Classification {
+	var result []job.Classification
+	for _, t := range types {
+		if found[t.Code] {
+			result = append(result, t)
+		}
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+package normalize
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/goodsun/jobscraper/job"
+)
+
+func TestParseLicenses(t *testing.T) {
+	// コードと必須かどうか
+	type license struct {
+		code     string
+		required bool
+	}
+	tests := []struct {
+		text string
+		want []license
+	}{
+		{"看護師免許必須、保健師あれば尚可", []license{{"nurse", true}, {"public_health_nurse", false}}},
+		{"看護師・准看護師（必須）", []license{{"nurse", true}, {"assistant_nurse", true}}},
+		{"看護師・准看護師歓迎", []license{{"nurse", false}, {"assistant_nurse", false}}},
+		// 「可」はその資格だけを歓迎にする
+		{"正看護師、准看護師可", []license{{"nurse", true}, {"assistant_nurse", false}}},
+		{"正看護師（准看護師も可）", []license{{"nurse", true}, {"assistant_nurse", false}}},
+		{"看護師免許必須（ブランク可）", []license{{"nurse", true}}},
+		{"正看護師（准看護師でも可）", []license{{"nurse", true}, {"assistant_nurse", false}}},
+		// 資格のすぐ後でない「可」は歓迎にしない
+		{"看護師（ブランク可）", []license{{"nurse", true}}},
+		{"看護師（未経験可）", []license{{"nurse", true}}},
+		// 「不可」の資格は入れない
+		{"准看護師不可", nil},
+		{"看護師・准看護師不可", []license{{"nurse", true}}},
+		// 「要相談」は必須にしない
+		{"看護師免許必須。准看護師は要相談", []license{{"nurse", true}, {"assistant_nurse", false}}},
+		{"要普通自動車免許", []license{{"drivers_license", true}}},
+		{"普通自動車免許（要）", []license{{"drivers_license", true}}},
+		// 必須と歓迎の両方に出てきたら必須
+		{"看護師必須。看護師歓迎", []license{{"nurse", true}}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		var got []license
+		for _, l := range ParseLicenses(tt.text) {
+			got = append(got, license{l.Code, l.Required})
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseLicenses(%q) = %v; want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseExperienceYears(t *testing.T) {
+	tests := []struct {
+		text  string
+		years int
+		ok    bool
+	}{
+		{"臨床経験3年以上", 3, true},
+		{"3年以上の実務経験", 3, true},
+		{"経験三年以上", 3, true},
+		{"未経験可", 0, true},
+		{"経験不問", 0, true},
+		{"看護師免許", 0, false},
+	}
+	for _, tt := range tests {
+		if years, ok := ParseExperienceYears(tt.text); years != tt.years || ok != tt.ok {
+			t.Errorf("ParseExperienceYears(%q) = %d, %v; want %d, %v", tt.text, years, ok, tt.years, tt.ok)
+		}
+	}
+}
+
+func TestQualificationsOccupationFromLicense(t *testing.T) {
+	data := job.JobData{License: "介護福祉士必須、普通自動車免許あれば尚可"}
+	Qualifications(&data)
+	var codes []string
+	for _, occupation := range data.Occupations {
+		codes = append(codes, occupation.Code)
+	}
+	if want := []string{"care_worker"}; !reflect.DeepEqual(codes, want) {
+		t.Errorf("Occupations = %v; want %v", codes, want)
+	}
+}
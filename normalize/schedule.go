@@ -0,0 +1,212 @@
+package normalize
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/goodsun/jobscraper/job"
+)
+
+var (
+	// 勤務時間帯（「8:30~17:30」「8時~17時30分」「16:30~翌9:00」）
+	shiftRegex = regexp.MustCompile(`(\d{1,2})\s*[:時]\s*(\d{2})?\s*分?\s*(?:[~〜-]|から)\s*(翌日?|翌朝)?\s*(\d{1,2})\s*[:時]\s*(\d{2})?\s*分?`)
+	// 休憩時間（「休憩60分」「休憩1時間」「休憩:45分」）
+	breakRegex = regexp.MustCompile(`休憩\s*(?:時間)?\s*[:：]?\s*(?:約)?\s*(\d+(?:\.\d+)?)\s*(分|時間|h)`)
+	// 曜日の後ろが休みの話
+	daysOffRegex = regexp.MustCompile(`^\s*(?:は|が)?\s*(?:休|定休|お休)`)
+
+	// 残業（「残業月平均10時間」「残業:月5h程度」「残業なし」）
+	overtimeRegex     = regexp.MustCompile(`残業[^\d。]{0,12}?(\d+(?:\.\d+)?)\s*(?:時間|h)`)
+	noOvertimeRegex   = regexp.MustCompile(`残業\s*(?:は|が)?\s*(?:ほぼ|ほとんど)?\s*(?:なし|無し|ゼロ|ありません)`)
+	noNightShiftRegex = regexp.MustCompile(`(?:夜勤|当直|宿直)\s*(?:は|が)?\s*(?:なし|無し|無|免除|ありません|ない|不要)`)
+	nightShiftRegex   = regexp.MustCompile(`夜勤|当直|宿直`)
+	noOnCallRegex     = regexp.MustCompile(`オンコール\s*(?:は|が)?\s*(?:なし|無し|無|ありません|ない|不要)`)
+)
+
+// 勤務形態の言葉（「日勤のみ可」は「日勤のみ」より先に調べる）
+var workingStyleRegexes = []struct {
+	style string
+	regex *regexp.Regexp
+}{
+	{"日勤のみ可", regexp.MustCompile(`日勤のみ\s*(?:も|の)?\s*(?:可|OK|相談|応相談|選択|歓迎)`)},
+	{"日勤のみ", regexp.MustCompile(`日勤のみ|日勤常勤|日勤専従|日勤帯のみ`)},
+	{"夜勤専従", regexp.MustCompile(`夜勤専従|夜勤のみ|夜専`)},
+	{"2交替", regexp.MustCompile(`[2二]交[替代]`)},
+	{"3交替", regexp.MustCompile(`[3三]交[替代]`)},
+	{"シフト制", regexp.MustCompile(`シフト`)},
+	{"変形労働時間制", regexp.MustCompile(`変形労働`)},
+	{"フレックスタイム制", regexp.MustCompile(`フレックス`)},
+	{"時短勤務", regexp.MustCompile(`時短|短時間勤務|短時間正`)},
+}
+
+// Schedule は WorkingHours から勤務時間帯・曜日を、WorkingHours・WorkingStyle・Contract・Name・Detail から
+// 勤務形態・夜勤・オンコール・残業時間を取り出す
+func Schedule(data *job.JobData) {
+	notes := strings.Join([]string{data.WorkingHours, data.WorkingStyle, data.Contract, data.Name, data.Detail}, "\n")
+	if strings.TrimSpace(notes) == "" {
+		return
+	}
+	schedule := ParseSchedule(data.WorkingHours, notes)
+	if len(schedule.Shifts) > 0 || len(schedule.Days) > 0 || len(schedule.Styles) > 0 || schedule.NightShift || schedule.OnCall || schedule.OvertimeHours != nil {
+		data.Schedule = &schedule
+	}
+}
+
+// ParseSchedule は勤務時間の文章（hours）から勤務時間帯・曜日を、
+// 勤務形態などの文章（notes。hours も含めて渡す）から勤務形態・夜勤・オンコール・残業時間を取り出す。
+// 夜勤の記載がなく、勤務時間帯がすべて日中（5時〜22時）なら日勤のみとする
+func ParseSchedule(hours string, notes string) job.Schedule {
+	var schedule job.Schedule
+	hours = unifyDashes(foldAddress(hours))
+	notes = unifyDashes(foldAddress(notes))
+
+	schedule.Shifts = parseShifts(hours)
+	schedule.Days = parseDays(hours)
+
+	// 勤務形態（否定の言葉は先に取り除く）
+	noNight := noNightShiftRegex.MatchString(notes)
+	rest := noNightShiftRegex.ReplaceAllString(notes, " ")
+	found := map[string]bool{}
+	for _, s := range workingStyleRegexes {
+		if s.regex.MatchString(rest) {
+			found[s.style] = true
+			rest = s.regex.ReplaceAllString(rest, " ")
+		}
+	}
+
+	// 夜勤（「日勤のみ可」は夜勤がある求人の書き方）
+	schedule.NightShift = found["夜勤専従"] || found["2交替"] || found["3交替"] || found["日勤のみ可"] || nightShiftRegex.MatchString(rest)
+	daytime := len(schedule.Shifts) > 0
+	for _, shift := range schedule.Shifts {
+		if shift.Overnight || shift.Start < "05:00" || shift.Start >= "20:00" || shift.End > "22:00" {
+			schedule.NightShift = schedule.NightShift || shift.Overnight || shift.Start >= "20:00"
+			daytime = false
+		}
+	}
+	schedule.DayOnly = !schedule.NightShift && (found["日勤のみ"] || noNight || daytime)
+	found["日勤のみ"] = schedule.DayOnly
+	for _, style := range job.WorkingStyleNames {
+		if found[style] {
+			schedule.Styles = append(schedule.Styles, style)
+		}
+	}
+
+	schedule.OnCall = strings.Contains(notes, "オンコール") && !noOnCallRegex.MatchString(notes)
+
+	if m := overtimeRegex.FindStringSubmatch(notes); m != nil {
+		if hours, err := strconv.ParseFloat(m[1], 64); err == nil {
+			schedule.OvertimeHours = &hours
+		}
+	} else if noOvertimeRegex.MatchString(notes) {
+		zero := 0.0
+		schedule.OvertimeHours = &zero
+	}
+	return schedule
+}
+
+// 勤務時間帯と、その後ろに書かれた休憩時間
+func parseShifts(text string) []job.Shift {
+	matches := shiftRegex.FindAllStringSubmatchIndex(text, -1)
+	var shifts []job.Shift
+	var breaks []int // 勤務時間帯ごとの休憩（分。なければ -1）
+	mentioned := 0
+	for i, m := range matches {
+		startHour, _ := strconv.Atoi(text[m[2]:m[3]])
+		endHour, _ := strconv.Atoi(text[m[8]:m[9]])
+		if startHour > 24 || endHour > 30 {
+			continue
+		}
+		shift := job.Shift{
+			Start:     clock(startHour, submatch(text, m, 2)),
+			Overnight: m[6] >= 0 || endHour >= 24,
+		}
+		if endHour >= 24 {
+			endHour -= 24
+		}
+		shift.End = clock(endHour, submatch(text, m, 5))
+		if shift.End <= shift.Start {
+			shift.Overnight = true
+		}
+
+		// 次の勤務時間帯までの休憩
+		next := len(text)
+		if i+1 < len(matches) {
+			next = matches[i+1][0]
+		}
+		minutes := -1
+		if b := breakRegex.FindStringSubmatch(text[m[1]:next]); b != nil {
+			minutes = breakMinutes(b)
+			mentioned++
+		}
+
+		duplicate := false
+		for _, s := range shifts {
+			if s.Start == shift.Start && s.End == shift.End {
+				duplicate = true
+			}
+		}
+		if !duplicate {
+			shifts = append(shifts, shift)
+			breaks = append(breaks, minutes)
+		}
+	}
+
+	// 休憩が最後に1つだけ書かれていれば、すべての勤務時間帯の休憩とする
+	if mentioned == 1 && len(breaks) > 0 && breaks[len(breaks)-1] >= 0 {
+		for i := range breaks {
+			breaks[i] = breaks[len(breaks)-1]
+		}
+	}
+	for i := range shifts {
+		if breaks[i] > 0 {
+			shifts[i].BreakMinutes = breaks[i]
+		}
+	}
+	return shifts
+}
+
+// n 番目のグループ（なければ空）
+func submatch(text string, m []int, n int) string {
+	if m[2*n] < 0 {
+		return ""
+	}
+	return text[m[2*n]:m[2*n+1]]
+}
+
+func clock(hour int, minute string) string {
+	if minute == "" {
+		minute = "00"
+	}
+	return fmt.Sprintf("%02d:%s", hour, minute)
+}
+
+func breakMinutes(m []string) int {
+	value, _ := strconv.ParseFloat(m[1], 64)
+	if m[2] != "分" {
+		value *= 60
+	}
+	return int(value)
+}
+
+// 勤務する曜日（月〜日の順）。休みの曜日（「土日祝休み」）は除く
+func parseDays(text string) []string {
+	found := map[string]bool{}
+	for _, run := range findDayRuns(text) {
+		if daysOffRegex.MatchString(text[run.end:]) {
+			continue
+		}
+		for _, day := range run.days {
+			found[day] = true
+		}
+	}
+
+	var days []string
+	for _, day := range weekdays {
+		if found[day] {
+			days = append(days, day)
+		}
+	}
+	return days
+}
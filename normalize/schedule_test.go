@@ -0,0 +1,77 @@
+package normalize
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/goodsun/jobscraper/job"
+)
+
+func TestParseSchedule(t *testing.T) {
+	hours := func(h float64) *float64 { return &h }
+	tests := []struct {
+		hours string
+		notes string
+		want  job.Schedule
+	}{
+		{
+			"8:30～17:00、16:30～翌9:00", "",
+			job.Schedule{
+				Shifts:     []job.Shift{{Start: "08:30", End: "17:00"}, {Start: "16:30", End: "09:00", Overnight: true}},
+				NightShift: true,
+			},
+		},
+		{
+			"9:00〜18:00（休憩60分）", "日勤のみ 残業月10時間程度",
+			job.Schedule{
+				Shifts:        []job.Shift{{Start: "09:00", End: "18:00", BreakMinutes: 60}},
+				Styles:        []string{"日勤のみ"},
+				DayOnly:       true,
+				OvertimeHours: hours(10),
+			},
+		},
+		{
+			"月〜金 9:00-17:30", "夜勤なし",
+			job.Schedule{
+				Shifts:  []job.Shift{{Start: "09:00", End: "17:30"}},
+				Days:    []string{"月", "火", "水", "木", "金"},
+				Styles:  []string{"日勤のみ"},
+				DayOnly: true,
+			},
+		},
+		{
+			"日勤 8:30~17:00 夜勤 16:30~9:00", "オンコールあり 残業なし",
+			job.Schedule{
+				Shifts:        []job.Shift{{Start: "08:30", End: "17:00"}, {Start: "16:30", End: "09:00", Overnight: true}},
+				NightShift:    true,
+				OnCall:        true,
+				OvertimeHours: hours(0),
+			},
+		},
+		{
+			"", "2交替制 夜勤あり",
+			job.Schedule{Styles: []string{"2交替"}, NightShift: true},
+		},
+		{
+			"10:00～15:00", "時短勤務可",
+			job.Schedule{
+				Shifts:  []job.Shift{{Start: "10:00", End: "15:00"}},
+				Styles:  []string{"日勤のみ", "時短勤務"},
+				DayOnly: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		if got := ParseSchedule(tt.hours, tt.hours+"\n"+tt.notes); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseSchedule(%q, %q) = %+v; want %+v", tt.hours, tt.notes, got, tt.want)
+		}
+	}
+}
+
+func TestScheduleWithoutText(t *testing.T) {
+	var data job.JobData
+	Schedule(&data)
+	if data.Schedule != nil {
+		t.Errorf("Schedule = %+v; want nil", data.Schedule)
+	}
+}
@@ -0,0 +1,306 @@
+package normalize
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goodsun/jobscraper/job"
+)
+
+var (
+	// 交通手段と所要時間（「徒歩約5分」「バスで10分」「車15〜20分」「駅直結」）
+	transitTimeRegex = regexp.MustCompile(`(徒歩|歩いて|バス|自転車|車)\s*(?:で|にて)?\s*(?:約|およそ)?\s*(\d+)\s*(?:分\s*)?(?:[~〜-]\s*(\d+)\s*)?分|(直結|直通|構内)`)
+
+	// 駅名（「新宿」駅・「新宿駅」・新宿駅・JR中央線中野駅・「新宿」）
+	stationRegex = regexp.MustCompile(`「([^「」]+?)」\s*駅|「([^「」]+?)駅」|([^\s「」『』()（）\[\]［］・、,/／:：]+?)駅|「([^「」]+?)」`)
+	// 路線名
+	lineRegex = regexp.MustCompile(`[^\s「」『』()（）\[\]［］・、,/／:：]+?(?:線|ライン)`)
+	// 駅名・路線名の前後に付く言葉
+	accessNoiseRegex = regexp.MustCompile(`最寄り?駅|最寄り?|から|より`)
+)
+
+// 路線名の先頭 → 事業者
+var operators = []struct{ prefix, name string }{
+	{"JR", "JR"},
+	{"東京メトロ", "東京メトロ"},
+	{"メトロ", "東京メトロ"},
+	{"都営", "都営地下鉄"},
+	{"東急", "東急"},
+	{"小田急", "小田急"},
+	{"京王", "京王"},
+	{"西武", "西武"},
+	{"東武", "東武"},
+	{"京急", "京急"},
+	{"新京成", "新京成"},
+	{"京成", "京成"},
+	{"相鉄", "相鉄"},
+	{"北総", "北総鉄道"},
+	{"東葉高速", "東葉高速鉄道"},
+	{"埼玉高速", "埼玉高速鉄道"},
+	{"りんかい", "東京臨海高速鉄道"},
+	{"つくばエクスプレス", "首都圏新都市鉄道"},
+	{"横浜市営", "横浜市交通局"},
+	{"阪急", "阪急"},
+	{"阪神", "阪神"},
+	{"近鉄", "近鉄"},
+	{"南海", "南海"},
+	{"京阪", "京阪"},
+	{"大阪メトロ", "Osaka Metro"},
+	{"Osaka Metro", "Osaka Metro"},
+	{"大阪市営", "Osaka Metro"},
+	{"名鉄", "名鉄"},
+	{"名古屋市営", "名古屋市交通局"},
+	{"西鉄", "西鉄"},
+	{"福岡市営", "福岡市交通局"},
+	{"札幌市営", "札幌市交通局"},
+	{"仙台市営", "仙台市交通局"},
+	{"神戸市営", "神戸市交通局"},
+	{"京都市営", "京都市交通局"},
+}
+
+// 駅名として扱わない言葉（「各駅停車」「主要駅」など）
+var notStations = map[string]bool{"各": true, "主要": true, "近隣": true, "複数": true, "最寄": true, "最寄り": true, "ターミナル": true}
+
+// Transit は Access・Station から駅ごとの路線・交通手段・所要時間を取り出し、最寄り駅をまとめる
+func Transit(data *job.JobData) {
+	text := strings.TrimSpace(data.Access + "\n" + data.Station)
+	if text == "" {
+		return
+	}
+	data.Transit = ParseAccess(text)
+	if nearest, ok := nearestTransit(data.Transit); ok && data.NearestStation == "" {
+		data.NearestStation = formatTransit(nearest)
+	}
+}
+
+// ParseAccess はアクセスの文章（「・中野坂上駅 (都営大江戸線) 徒歩7分・JR中央線「中野」駅からバス10分」）を駅ごとに分ける。
+// 同じ駅・路線・交通手段は1つにまとめ、所要時間は短い方を使う
+func ParseAccess(text string) []job.Transit {
+	text = unifyDashes(foldAddress(text))
+
+	// 所要時間までを1区間として、区間ごとに駅を探す
+	var transits []job.Transit
+	start := 0
+	for _, m := range transitTimeRegex.FindAllStringSubmatchIndex(text, -1) {
+		mode, minutes, ok := transitTime(text, m)
+		if !ok {
+			continue
+		}
+		segment := text[start:m[0]]
+		start = m[1]
+		found := parseStations(segment)
+		if len(found) == 0 {
+			// 「バス10分「○○」下車徒歩3分」のように駅のない区間は、直前の駅からの所要時間に足す
+			if n := len(transits); n > 0 && transits[n-1].Mode != "" {
+				transits[n-1].Minutes += minutes
+			}
+			continue
+		}
+		for i := range found {
+			found[i].Mode, found[i].Minutes = mode, minutes
+		}
+		transits = append(transits, found...)
+	}
+	transits = append(transits, parseStations(text[start:])...)
+	return mergeTransits(transits)
+}
+
+// 所要時間の一致から交通手段と分数を取り出す（範囲は長い方を使う）
+func transitTime(text string, m []int) (string, int, bool) {
+	if m[8] >= 0 {
+		return "徒歩", 0, true
+	}
+	mode := text[m[2]:m[3]]
+	switch mode {
+	case "歩いて":
+		mode = "徒歩"
+	case "車":
+		// 「電車で10分」「下車」の「車」は交通手段ではない
+		if previous, _ := utf8.DecodeLastRuneInString(text[:m[2]]); strings.ContainsRune("電列乗下発停駐", previous) {
+			return "", 0, false
+		}
+	}
+	minutes, _ := strconv.Atoi(text[m[4]:m[5]])
+	if m[6] >= 0 {
+		if upper, err := strconv.Atoi(text[m[6]:m[7]]); err == nil && upper > minutes {
+			minutes = upper
+		}
+	}
+	return mode, minutes, true
+}
+
+type stationMatch struct {
+	name       string
+	line       string // 駅名の直前に続けて書かれた路線名
+	start, end int
+}
+
+// 区間の中の駅と、その駅の路線
+func parseStations(segment string) []job.Transit {
+	segment = accessNoiseRegex.ReplaceAllString(segment, " ")
+	// バスの区間の「」はバス停名
+	busStop := strings.Contains(segment, "バス") || strings.Contains(segment, "停") || strings.Contains(segment, "下車")
+
+	var stations []stationMatch
+	for _, m := range stationRegex.FindAllStringSubmatchIndex(segment, -1) {
+		match := stationMatch{start: m[0], end: m[1]}
+		switch {
+		case m[2] >= 0:
+			match.name = segment[m[2]:m[3]]
+		case m[4] >= 0:
+			match.name = segment[m[4]:m[5]]
+		case m[6] >= 0:
+			match.name = segment[m[6]:m[7]]
+			// 「JR中央線中野駅」のように路線名に続けて書かれた駅名
+			if i := lastLineEnd(match.name); i > 0 {
+				match.line, match.name = match.name[:i], match.name[i:]
+			}
+		case busStop:
+			continue
+		default:
+			match.name = segment[m[8]:m[9]]
+		}
+		match.name = strings.TrimSpace(match.name)
+		if match.name == "" || notStations[match.name] {
+			continue
+		}
+		stations = append(stations, match)
+	}
+
+	// 駅名より前（前の駅より後ろ）の路線名、なければ駅名より後ろ（次の駅より前）の路線名をその駅のものとする
+	var transits []job.Transit
+	from := 0
+	for i, station := range stations {
+		lines := []string{station.line}
+		if station.line == "" {
+			lines = findLines(segment[from:station.start])
+			from = station.end
+			if len(lines) == 0 {
+				next := len(segment)
+				if i+1 < len(stations) {
+					next = stations[i+1].start
+				}
+				if m := lineRegex.FindAllStringIndex(segment[station.end:next], -1); len(m) > 0 {
+					lines = findLines(segment[station.end:next])
+					from = station.end + m[len(m)-1][1]
+				}
+			}
+		} else {
+			from = station.end
+		}
+		if len(lines) == 0 {
+			lines = []string{""}
+		}
+		for _, line := range lines {
+			transits = append(transits, job.Transit{Station: station.name, Line: line, Operator: lineOperator(line)})
+		}
+	}
+	return transits
+}
+
+// 路線名の一覧（「沿線」「各線」のような路線名でないものは除く）
+func findLines(text string) []string {
+	var lines []string
+	for _, line := range lineRegex.FindAllString(text, -1) {
+		switch line {
+		case "沿線", "各線", "路線", "全線":
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// 駅名の前に付いた路線名の終わり（なければ 0）
+func lastLineEnd(name string) int {
+	end := 0
+	for _, suffix := range []string{"線", "ライン"} {
+		if i := strings.LastIndex(name, suffix); i >= 0 && i+len(suffix) > end && i+len(suffix) < len(name) {
+			end = i + len(suffix)
+		}
+	}
+	return end
+}
+
+func lineOperator(line string) string {
+	for _, operator := range operators {
+		if strings.HasPrefix(line, operator.prefix) {
+			return operator.name
+		}
+	}
+	return ""
+}
+
+// 同じ駅・路線・交通手段をまとめ、路線や所要時間の分からない重複を除く
+func mergeTransits(transits []job.Transit) []job.Transit {
+	var merged []job.Transit
+	for _, t := range transits {
+		duplicate := false
+		for i := range merged {
+			if merged[i].Station == t.Station && merged[i].Line == t.Line && merged[i].Mode == t.Mode {
+				if t.Minutes < merged[i].Minutes {
+					merged[i].Minutes = t.Minutes
+				}
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			merged = append(merged, t)
+		}
+	}
+
+	var result []job.Transit
+	for i, t := range merged {
+		covered := false
+		for j, other := range merged {
+			if i != j && other.Station == t.Station && (t.Line == "" || t.Line == other.Line) && (t.Mode == "" || t.Mode == other.Mode) &&
+				(other.Line != t.Line || other.Mode != t.Mode) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// 最寄り駅（徒歩で最も近い駅。徒歩がなければ所要時間の最も短い駅、所要時間がなければ最初の駅）
+func nearestTransit(transits []job.Transit) (job.Transit, bool) {
+	var nearest *job.Transit
+	rank := func(t *job.Transit) int {
+		switch {
+		case t.Mode == "徒歩":
+			return 0
+		case t.Mode != "":
+			return 1
+		}
+		return 2
+	}
+	for i := range transits {
+		t := &transits[i]
+		if nearest == nil || rank(t) < rank(nearest) || (rank(t) == rank(nearest) && t.Mode != "" && t.Minutes < nearest.Minutes) {
+			nearest = t
+		}
+	}
+	if nearest == nil {
+		return job.Transit{}, false
+	}
+	return *nearest, true
+}
+
+// "中野坂上駅 徒歩7分"
+func formatTransit(t job.Transit) string {
+	switch {
+	case t.Mode == "":
+		return t.Station + "駅"
+	case t.Mode == "徒歩" && t.Minutes == 0:
+		return t.Station + "駅 直結"
+	}
+	return fmt.Sprintf("%s駅 %s%d分", t.Station, t.Mode, t.Minutes)
+}
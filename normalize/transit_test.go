@@ -0,0 +1,65 @@
+package normalize
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/goodsun/jobscraper/job"
+)
+
+func TestParseAccess(t *testing.T) {
+	tests := []struct {
+		text string
+		want []job.Transit
+	}{
+		{
+			"・中野坂上駅 (都営大江戸線) 徒歩7分・JR中央線「中野」駅からバス10分",
+			[]job.Transit{
+				{Station: "中野坂上", Line: "都営大江戸線", Operator: "都営地下鉄", Mode: "徒歩", Minutes: 7},
+				{Station: "中野", Line: "JR中央線", Operator: "JR", Mode: "バス", Minutes: 10},
+			},
+		},
+		{
+			"東京メトロ丸ノ内線 新宿駅 徒歩5分、JR山手線 新宿駅 徒歩8分",
+			[]job.Transit{
+				{Station: "新宿", Line: "東京メトロ丸ノ内線", Operator: "東京メトロ", Mode: "徒歩", Minutes: 5},
+				{Station: "新宿", Line: "JR山手線", Operator: "JR", Mode: "徒歩", Minutes: 8},
+			},
+		},
+		{
+			// 駅のない区間（バス停から徒歩）は直前の駅の所要時間に足す
+			"JR「大阪」駅よりバス15分「梅田二丁目」下車徒歩3分",
+			[]job.Transit{{Station: "大阪", Mode: "バス", Minutes: 18}},
+		},
+		{
+			"京王線 調布駅より車で10分",
+			[]job.Transit{{Station: "調布", Line: "京王線", Operator: "京王", Mode: "車", Minutes: 10}},
+		},
+		{
+			// 同じ駅・交通手段は短い方の所要時間にまとめる
+			"渋谷駅 徒歩3分 / 渋谷駅 徒歩5分",
+			[]job.Transit{{Station: "渋谷", Mode: "徒歩", Minutes: 3}},
+		},
+		{"各駅停車 徒歩5分", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := ParseAccess(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseAccess(%q) = %+v; want %+v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestTransitNearestStation(t *testing.T) {
+	data := job.JobData{Access: "JR山手線 新宿駅 徒歩8分、東京メトロ丸ノ内線 新宿駅 徒歩5分"}
+	Transit(&data)
+	if data.NearestStation != "新宿駅 徒歩5分" {
+		t.Errorf("NearestStation = %q; want %q", data.NearestStation, "新宿駅 徒歩5分")
+	}
+
+	data = job.JobData{Access: "渋谷駅 徒歩3分", NearestStation: "恵比寿駅 徒歩10分"}
+	Transit(&data)
+	if data.NearestStation != "恵比寿駅 徒歩10分" {
+		t.Errorf("NearestStation = %q; want it to be kept", data.NearestStation)
+	}
+}
@@ -0,0 +1,97 @@
+package normalize
+
+import (
+	"errors"
+	"io/fs"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/goodsun/jobscraper/config"
+	"github.com/goodsun/jobscraper/job"
+)
+
+// キーワードの直後の否定（「退職金なし」「車通勤不可」）
+var negationRegex = regexp.MustCompile(`^\s*(?:は|が|の)?\s*(?:なし|無し|不可|ありません|NG|対象外)`)
+
+var (
+	defaultWelfareOnce       sync.Once
+	defaultWelfareDictionary *config.WelfareDictionary
+	defaultWelfareErr        error
+)
+
+// 設定の検索パスの welfare.json
+func defaultWelfare() (*config.WelfareDictionary, error) {
+	defaultWelfareOnce.Do(func() {
+		defaultWelfareDictionary, defaultWelfareErr = loadWelfare(config.DefaultSearchPath())
+	})
+	return defaultWelfareDictionary, defaultWelfareErr
+}
+
+// 検索パスの welfare.json を読む（どこにもなければ空の辞書。壊れていればエラー）
+func loadWelfare(search config.SearchPath) (*config.WelfareDictionary, error) {
+	dictionary, err := search.WelfareDictionary()
+	if errors.Is(err, fs.ErrNotExist) {
+		return config.EmptyWelfareDictionary(), nil
+	}
+	return dictionary, err
+}
+
+// Welfare は WelfareProgram・Price・Access・Detail に辞書のタグを付ける Normalizer を返す
+// （nil なら設定の検索パスの welfare.json。読み込めなければ Normalize がエラーを返す）
+func Welfare(dictionary *config.WelfareDictionary) Normalizer {
+	return welfareNormalizer{dictionary}
+}
+
+type welfareNormalizer struct {
+	dictionary *config.WelfareDictionary
+}
+
+func (n welfareNormalizer) Normalize(data *job.JobData) error {
+	dictionary := n.dictionary
+	if dictionary == nil {
+		var err error
+		if dictionary, err = defaultWelfare(); err != nil {
+			return err
+		}
+	}
+	text := strings.Join([]string{data.WelfareProgram, data.Price, data.Access, data.Detail}, "\n")
+	if tags := TagWelfare(dictionary, text); len(tags) > 0 {
+		data.WelfareTags = tags
+	}
+	return nil
+}
+
+// TagWelfare は文章に辞書のタグを付け、辞書の順に並べる
+func TagWelfare(dictionary *config.WelfareDictionary, text string) []string {
+	text = foldAddress(text)
+	var tags []string
+	for _, tag := range dictionary.Tags {
+		target := text
+		for _, exclude := range tag.Exclude {
+			target = strings.ReplaceAll(target, foldAddress(exclude), " ")
+		}
+		if containsKeyword(target, tag.Keywords) {
+			tags = append(tags, tag.Tag)
+		}
+	}
+	return tags
+}
+
+// キーワードのどれかが、否定されずに出てくるか
+func containsKeyword(text string, keywords []string) bool {
+	for _, keyword := range keywords {
+		keyword = foldAddress(keyword)
+		for rest := text; ; {
+			i := strings.Index(rest, keyword)
+			if i < 0 {
+				break
+			}
+			rest = rest[i+len(keyword):]
+			if !negationRegex.MatchString(rest) {
+				return true
+			}
+		}
+	}
+	return false
+}
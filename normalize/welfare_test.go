@@ -0,0 +1,57 @@
+package normalize
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/goodsun/jobscraper/config"
+)
+
+func TestTagWelfare(t *testing.T) {
+	dictionary, err := config.LoadWelfareDictionary("../configs/welfare.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"社会保険完備、賞与年2回、退職金制度あり", []string{"社会保険完備", "賞与", "退職金"}},
+		// 「全額」なら「交通費支給」は付けない
+		{"交通費全額支給", []string{"交通費全額支給"}},
+		{"通勤手当全額支給、駐車場あり", []string{"交通費全額支給", "駐車場"}},
+		{"交通費支給（上限3万円）", []string{"交通費支給"}},
+		{"交通費自己負担", nil},
+		// 資格名の「研修」は研修制度にしない
+		{"介護職員初任者研修修了者", nil},
+		{"実務者研修修了の方", nil},
+		{"新人研修あり、プリセプター制度", []string{"研修制度"}},
+		{"院内研修充実", []string{"研修制度"}},
+		// 否定が続くものは除く
+		{"車通勤不可、制服貸与", []string{"制服貸与"}},
+		{"寮なし", nil},
+	}
+	for _, tt := range tests {
+		if got := TagWelfare(dictionary, tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("TagWelfare(%q) = %q; want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestLoadWelfare(t *testing.T) {
+	// どこにも welfare.json がなければ空の辞書
+	dictionary, err := loadWelfare(config.SearchPath{{Name: "missing", FS: fstest.MapFS{}}})
+	if err != nil || dictionary == nil || len(dictionary.Tags) != 0 {
+		t.Errorf("loadWelfare(missing) = %v, %v; want an empty dictionary", dictionary, err)
+	}
+
+	// 壊れた welfare.json はエラーにする
+	broken := config.SearchPath{{Name: "broken", FS: fstest.MapFS{
+		"welfare.json": {Data: []byte(`{"tags": [{"tag": "賞与"}]}`)},
+	}}}
+	if _, err := loadWelfare(broken); err == nil || !strings.Contains(err.Error(), "welfare.json") {
+		t.Errorf("loadWelfare(broken) error = %v; want one naming welfare.json", err)
+	}
+}
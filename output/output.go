@@ -0,0 +1,69 @@
+// Package output は抽出結果の書き出しを行う。
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// インデント付きJSONに変換する
+func JSON(v interface{}) ([]byte, error) {
+	return json.MarshalIndent(v, "", "    ")
+}
+
+// 設定をインデント付きJSONに変換する（手で編集できるよう、セレクターや正規表現の "<" ">" "&" はエスケープしない）
+func ConfigJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "    ")
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// JSONをファイルに保存する（ファイル名が空なら標準出力）
+func WriteJSON(v interface{}, outputFile string) error {
+	jsonData, err := JSON(v)
+	if err != nil {
+		return err
+	}
+	return write(jsonData, outputFile)
+}
+
+// 設定のJSONをファイルに保存する（ファイル名が空なら標準出力）
+func WriteConfig(v interface{}, outputFile string) error {
+	jsonData, err := ConfigJSON(v)
+	if err != nil {
+		return err
+	}
+	return write(jsonData, outputFile)
+}
+
+func write(jsonData []byte, outputFile string) error {
+	if outputFile == "" {
+		fmt.Println(string(jsonData))
+		return nil
+	}
+	return os.WriteFile(outputFile, jsonData, 0644)
+}
+
+// JSON Lines形式でファイルに追記する
+func AppendJSONLines(outputFile string, records []interface{}) error {
+	file, err := os.OpenFile(outputFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
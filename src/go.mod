@@ -1,26 +0,0 @@
-module universal-extractor
-
-go 1.23.0
-
-toolchain go1.23.10
-
-require (
-	github.com/PuerkitoBio/goquery v1.10.3
-	github.com/antchfx/htmlquery v1.3.4
-	github.com/chromedp/chromedp v0.13.7
-	golang.org/x/net v0.41.0
-	golang.org/x/text v0.26.0
-)
-
-require (
-	github.com/andybalholm/cascadia v1.3.3 // indirect
-	github.com/antchfx/xpath v1.3.3 // indirect
-	github.com/chromedp/cdproto v0.0.0-20250403032234-65de8f5d025b // indirect
-	github.com/chromedp/sysutil v1.1.0 // indirect
-	github.com/go-json-experiment/json v0.0.0-20250211171154-1ae217ad3535 // indirect
-	github.com/gobwas/httphead v0.1.0 // indirect
-	github.com/gobwas/pool v0.2.1 // indirect
-	github.com/gobwas/ws v1.4.0 // indirect
-	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da // indirect
-	golang.org/x/sys v0.33.0 // indirect
-)
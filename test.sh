@@ -1,11 +1,12 @@
 #!/bin/sh
 
 # ビルド
-echo "Building job-extractor..."
-go build -o job-extractor src/universal-extractor.go || exit 1
+echo "Building jobscraper..."
+go build -o jobscraper ./cmd/jobscraper || exit 1
 
 # 出力ディレクトリ作成
 mkdir -p output/test
+rm -f output/test/unmapped-labels.jsonl
 
 # テスト関数
 test_site() {
@@ -14,7 +15,7 @@ test_site() {
     
     echo ""
     echo "===== Testing $site_name ====="
-    ./job-extractor "$url" "output/test/${site_name}.json"
+    ./jobscraper extract --label-log output/test/unmapped-labels.jsonl "$url" "output/test/${site_name}.json"
     
     if [ $? -eq 0 ]; then
         echo "✓ $site_name: Success"
@@ -48,3 +49,7 @@ test_site "benesse-mcm" "https://kango.benesse-mcm.jp/p13/c0399/fac008783/jobN13
 
 echo ""
 echo "All tests completed. Results saved in output/test/"
+
+# ラベル辞書に未登録のラベルを表示
+echo ""
+./jobscraper list-unmapped-labels output/test/unmapped-labels.jsonl